@@ -16,9 +16,38 @@ limitations under the License.
 
 package main
 
-import "flag"
+import (
+	"flag"
+	"time"
+)
 
 func init() {
 	flag.StringVar(&configFile, "config", "",
 		"The controller will load its initial configuration from this file. ")
+	flag.IntVar(&workers, "workers", 1,
+		"The number of placement requests processed concurrently. Requests touching "+
+			"the same pods or nodes are never processed concurrently.")
+	flag.IntVar(&bindingConcurrency, "binding-concurrency", 1,
+		"The number of bindings of a single Lenient placement request bound concurrently.")
+	flag.IntVar(&maxRetries, "max-retries", 5,
+		"The maximum number of times an API call failing with a transient error is retried.")
+	flag.StringVar(&metricsBindAddress, "metrics-bind-address", ":8080",
+		"The address the metrics endpoint binds to. Set it to \"0\" to disable it.")
+	flag.StringVar(&webhookBindAddress, "webhook-bind-address", "0",
+		"The address the placement request validating webhook binds to. Set it to \"0\" to disable it.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/etc/kombiner/webhook",
+		"The directory holding the webhook serving certificate and key, named tls.crt and tls.key.")
+	flag.BoolVar(&leaderElect, "leader-elect", true,
+		"Start a leader election client and gain leadership before binding any pod. "+
+			"Enable this when running replicated controllers for high availability.")
+	flag.StringVar(&leaseName, "leader-elect-lease-name", "kombiner-controller",
+		"The name of the Lease object used for leader election.")
+	flag.StringVar(&leaseNamespace, "leader-elect-lease-namespace", "kube-system",
+		"The namespace of the Lease object used for leader election.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"The duration non-leader candidates wait before forcing to acquire leadership.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"The duration the leader retries refreshing leadership before giving it up.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"The duration candidates wait between attempts of acquiring and renewing leadership.")
 }
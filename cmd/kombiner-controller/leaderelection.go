@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"k8s.io/klog/v2"
+)
+
+var (
+	leaderElect    bool
+	leaseName      string
+	leaseNamespace string
+	leaseDuration  time.Duration
+	renewDeadline  time.Duration
+	retryPeriod    time.Duration
+)
+
+// runWithLeaderElection blocks until we become the leader and then calls the
+// provided run function. The context passed to run is cancelled as soon as we
+// lose the lease, in such case we exit the process so we can start over as a
+// standby replica. This function returns only when the provided context is
+// done.
+func runWithLeaderElection(
+	ctx context.Context, logger klog.Logger, kubecli kubernetes.Interface, run func(context.Context),
+) error {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("failed to get hostname: %w", err)
+	}
+	identity := hostname + "_" + string(uuid.NewUUID())
+
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		leaseNamespace,
+		leaseName,
+		kubecli.CoreV1(),
+		kubecli.CoordinationV1(),
+		resourcelock.ResourceLockConfig{Identity: identity},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create leader election lock: %w", err)
+	}
+
+	elector, err := leaderelection.NewLeaderElector(
+		leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			Name:            leaseName,
+			LeaseDuration:   leaseDuration,
+			RenewDeadline:   renewDeadline,
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(ctx context.Context) {
+					logger.Info("started leading", "identity", identity)
+					run(ctx)
+				},
+				OnStoppedLeading: func() {
+					// if we are shutting down this is expected, otherwise
+					// we have lost the lease and must stop immediately.
+					if ctx.Err() != nil {
+						logger.Info("stopped leading", "identity", identity)
+						return
+					}
+					logger.Info("leader election lost, exiting", "identity", identity)
+					klog.FlushAndExit(klog.ExitFlushTimeout, 1)
+				},
+				OnNewLeader: func(current string) {
+					if current == identity {
+						return
+					}
+					logger.Info("new leader elected", "identity", current)
+				},
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create leader elector: %w", err)
+	}
+
+	logger.Info("waiting for leadership", "lease", leaseNamespace+"/"+leaseName, "identity", identity)
+	elector.Run(ctx)
+	return nil
+}
@@ -25,11 +25,14 @@ import (
 	"runtime"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apimachineryruntime "k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -39,13 +42,17 @@ import (
 	"kombiner/pkg/controller"
 	clientset "kombiner/pkg/generated/clientset/versioned"
 	informers "kombiner/pkg/generated/informers/externalversions"
+	"kombiner/pkg/webhook"
 )
 
 var (
-	scheme     = apimachineryruntime.NewScheme()
-	KubeConfig string
-	Version    = "0.1.0"
-	configFile string
+	scheme             = apimachineryruntime.NewScheme()
+	KubeConfig         string
+	Version            = "0.1.0"
+	configFile         string
+	workers            int
+	bindingConcurrency int
+	maxRetries         int
 )
 
 func init() {
@@ -71,6 +78,11 @@ func main() {
 		return
 	}
 
+	if err := checkQueueAccess(config); err != nil {
+		logger.Error(err, "queue access rules require the webhook, see --webhook-bind-address")
+		return
+	}
+
 	kubeConfig := ctrl.GetConfigOrDie()
 	if kubeConfig.UserAgent == "" {
 		kubeConfig.UserAgent = fmt.Sprintf("kombiner/%s (%s/%s)", Version, runtime.GOOS, runtime.GOARCH)
@@ -88,6 +100,14 @@ func main() {
 		return
 	}
 
+	// events go through the broadcaster correlator, it aggregates similar
+	// events and rate limits them per object.
+	broadcaster := record.NewBroadcaster(record.WithContext(ctx))
+	broadcaster.StartStructuredLogging(4)
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubecli.CoreV1().Events("")})
+	defer broadcaster.Shutdown()
+	recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: controller.ComponentName})
+
 	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(kubecli, time.Second*30)
 	prInformerFactory := informers.NewSharedInformerFactory(prcli, time.Second*30)
 
@@ -97,18 +117,45 @@ func main() {
 		prcli,
 		kubecli.CoreV1(),
 		prInformerFactory.Kombiner().V1alpha1().PlacementRequests(),
-		kubeInformerFactory.Core().V1().Pods().Lister(),
+		kubeInformerFactory.Core().V1().Pods(),
+		kubeInformerFactory.Core().V1().Nodes().Lister(),
+		controller.WithWorkers(workers),
+		controller.WithBindingConcurrency(bindingConcurrency),
+		controller.WithMaxRetries(maxRetries),
+		controller.WithEventRecorder(recorder),
+		controller.WithInformerFactory(kubeInformerFactory),
 	)
 	if err != nil {
 		logger.Error(err, "error creating controller")
 		return
 	}
 
+	serveMetrics(ctx, logger, controller.DebugHandler())
+
 	kubeInformerFactory.Start(ctx.Done())
 	prInformerFactory.Start(ctx.Done())
 
-	logger.Info("controller started, waiting for events")
-	controller.Run(ctx)
+	// we need the caches synced before running as the controller builds
+	// its queues out of them.
+	kubeInformerFactory.WaitForCacheSync(ctx.Done())
+	prInformerFactory.WaitForCacheSync(ctx.Done())
+
+	// every replica serves the webhook, not only the leader.
+	validator := webhook.New(logger, config, kubeInformerFactory.Core().V1().Pods().Lister(), kubecli.CoreV1())
+	if err := serveWebhook(ctx, logger, validator); err != nil {
+		logger.Error(err, "error starting webhook server")
+		return
+	}
+
+	if !leaderElect {
+		logger.Info("controller started, waiting for events")
+		controller.Run(ctx)
+		return
+	}
+
+	if err := runWithLeaderElection(ctx, logger, kubecli, controller.Run); err != nil {
+		logger.Error(err, "error running leader election")
+	}
 }
 
 func getConfig(configFile string, logger klog.Logger) (configapi.Configuration, error) {
@@ -0,0 +1,66 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"kombiner/pkg/metrics"
+)
+
+var metricsBindAddress string
+
+// serveMetrics starts an http server exposing the prometheus metrics on the
+// configured address, the provided debug handler is served under
+// /debug/queues. The server is shut down when the provided context is done.
+// Setting the address to "0" disables the server.
+func serveMetrics(ctx context.Context, logger klog.Logger, debug http.Handler) {
+	if metricsBindAddress == "0" || metricsBindAddress == "" {
+		logger.Info("metrics server disabled")
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	mux.Handle("/debug/queues", debug)
+	server := &http.Server{
+		Addr:              metricsBindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "failed to shut down metrics server")
+		}
+	}()
+
+	go func() {
+		logger.Info("serving metrics", "address", metricsBindAddress)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "metrics server failed")
+		}
+	}()
+}
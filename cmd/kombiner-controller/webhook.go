@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/webhook"
+)
+
+var (
+	webhookBindAddress string
+	webhookCertDir     string
+)
+
+// serveWebhook starts an https server exposing the PlacementRequest validating
+// webhook on the configured address. The certificate and key are read from
+// tls.crt and tls.key in the configured directory and reloaded when they
+// change. The server is shut down when the provided context is done. Setting
+// the address to "0" disables the server.
+func serveWebhook(ctx context.Context, logger klog.Logger, handler http.Handler) error {
+	if !webhookEnabled() {
+		logger.Info("webhook server disabled")
+		return nil
+	}
+
+	watcher, err := certwatcher.New(
+		filepath.Join(webhookCertDir, "tls.crt"),
+		filepath.Join(webhookCertDir, "tls.key"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook certificate: %w", err)
+	}
+
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			logger.Error(err, "webhook certificate watcher failed")
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle(webhook.Path, handler)
+	server := &http.Server{
+		Addr:              webhookBindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: watcher.GetCertificate,
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "failed to shut down webhook server")
+		}
+	}()
+
+	go func() {
+		logger.Info("serving webhook", "address", webhookBindAddress)
+		if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(err, "webhook server failed")
+		}
+	}()
+	return nil
+}
+
+// webhookEnabled returns true if the webhook server has been configured.
+func webhookEnabled() bool {
+	return webhookBindAddress != "0" && webhookBindAddress != ""
+}
+
+// checkQueueAccess makes sure the queue access rules can be enforced. They are
+// only enforced by the webhook, without it anyone could use any queue.
+func checkQueueAccess(config configapi.Configuration) error {
+	if webhookEnabled() {
+		return nil
+	}
+
+	for _, queue := range config.Queues {
+		if queue.Access != nil {
+			return fmt.Errorf("queue %s sets access rules but the webhook is disabled", queue.SchedulerName)
+		}
+	}
+	return nil
+}
@@ -18,11 +18,14 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 // +k8s:defaulter-gen=true
 // +kubebuilder:object:root=true
 
+// FairnessAlgorithm is the name of a fairness algorithm. Besides the ones
+// listed below out-of-tree algorithms may be registered in the queue package.
 type FairnessAlgorithm string
 
 const (
@@ -35,6 +38,26 @@ const (
 	// manner. It uses a weighted random selection algorithm to determine the
 	// next placement request to process.
 	Uniform FairnessAlgorithm = "Uniform"
+
+	// DeficitRoundRobin is an algorithm that visits the queues in a round
+	// robin fashion granting each one of them a quantum of bindings per
+	// round. Unused quantum is carried over to the next round so queues
+	// holding placement requests larger than their quantum are still
+	// served, just less often.
+	DeficitRoundRobin FairnessAlgorithm = "DeficitRoundRobin"
+
+	// WeightedFairQueueing is an algorithm that stamps each placement
+	// request with a virtual finish time computed out of its number of
+	// bindings and its queue weight. The placement request with the
+	// smallest stamp is always served first.
+	WeightedFairQueueing FairnessAlgorithm = "WeightedFairQueueing"
+
+	// DominantResource is an algorithm that charges each queue for the
+	// resources requested by the pods it binds. The queue with the lowest
+	// dominant share of the cluster CPU, memory and extended resources,
+	// relative to its weight, is served next. Charges are kept for as long
+	// as the controller runs.
+	DominantResource FairnessAlgorithm = "DominantResource"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -48,13 +71,135 @@ type Configuration struct {
 	// FairnessAlgorithm defines the algorithm used by the kombiner
 	// controller when selecting the next PlacementRequest to process.
 	// Fairness is controlled by this field. The default value, if not
-	// specified, is RoundRobin.
-	// +kubebuilder:validation:Enum=RoundRobin;Uniform
+	// specified, is RoundRobin. It must be the name of a registered
+	// algorithm.
 	FairnessAlgorithm FairnessAlgorithm `json:"fairnessAlgorithm,omitempty"`
 
-	// Plugins captures a configuration for cluster wide validation
+	// FairnessAlgorithmArgs holds the parameters of the fairness algorithm.
+	// Its content is specific to each algorithm, the built-in ones take no
+	// parameters.
+	// +optional
+	FairnessAlgorithmArgs *runtime.RawExtension `json:"fairnessAlgorithmArgs,omitempty"`
+
+	// FairnessState configures how long the controller remembers the
+	// bindings served for each scheduler. With it fairness is measured
+	// over a time window instead of only while the queues are busy. Only
+	// the RoundRobin and DeficitRoundRobin algorithms make use of it.
+	// +optional
+	FairnessState *FairnessState `json:"fairnessState,omitempty"`
+
+	// Tiers lists the names of the queue tiers, from the highest to the
+	// lowest. Queues in a higher tier are always drained before queues in
+	// a lower one, the fairness algorithm only applies among queues in the
+	// same tier. Queues without a tier are placed below all tiers.
+	// +optional
+	Tiers []string `json:"tiers,omitempty"`
+
+	// ExpressLane, if set, lets placement requests for system critical
+	// pods bypass the fairness algorithm.
+	// +optional
+	ExpressLane *ExpressLane `json:"expressLane,omitempty"`
+
+	// Plugins captures a configuration for cluster wide validation. The
+	// validate plugins are merged with the ones configured for each queue,
+	// a plugin disabled in either of them is not run.
 	// +optional
 	Plugins Plugins `json:"plugins,omitempty"`
+
+	// AllowCordonedNodes lets pods be bound to cordoned (unschedulable)
+	// nodes. By default bindings targeting a cordoned node fail unless
+	// the pod tolerates the node.kubernetes.io/unschedulable taint.
+	// +optional
+	AllowCordonedNodes bool `json:"allowCordonedNodes,omitempty"`
+
+	// TTLSecondsAfterFinished limits the lifetime of a finished placement
+	// request. Once a placement request has finished (has a result) and
+	// this amount of seconds has passed it is deleted by the controller.
+	// Placement requests may override this value. If not set finished
+	// placement requests are never deleted by the controller. It can't be
+	// lower than five seconds so the scheduler gets to read the result.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// FairnessState configures the fairness history kept by the controller.
+type FairnessState struct {
+	// Window is the amount of time the bindings served for a scheduler
+	// are taken into account.
+	Window metav1.Duration `json:"window"`
+
+	// ConfigMap, if set, is where the fairness history is saved so it
+	// survives controller restarts and leader changes. Only the leader
+	// saves it, periodically, what was recorded since the last save is
+	// lost when the leader goes away.
+	// +optional
+	ConfigMap *ConfigMapReference `json:"configMap,omitempty"`
+}
+
+// ExpressLane configures which placement requests go into the express queue.
+// The express queue is always served before any other queue, up to its rate
+// limit. A placement request matching any of the rules goes express.
+type ExpressLane struct {
+	// MinPriority makes placement requests whose pods all have at least
+	// this priority go express, e.g. 2000000000 for the system critical
+	// priority classes.
+	// +optional
+	MinPriority *int32 `json:"minPriority,omitempty"`
+
+	// Namespaces makes placement requests in these namespaces go express.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// PriorityClassNames makes placement requests whose pods all use one
+	// of these priority classes go express, e.g. system-node-critical.
+	// +optional
+	PriorityClassNames []string `json:"priorityClassNames,omitempty"`
+
+	// DaemonSets makes placement requests whose pods are all owned by a
+	// DaemonSet go express.
+	// +optional
+	DaemonSets bool `json:"daemonSets,omitempty"`
+
+	// QPS is the amount of bindings per second served out of the express
+	// queue. Defaults to 10.
+	// +optional
+	QPS int32 `json:"qps,omitempty"`
+
+	// Burst is the maximum amount of bindings served out of the express
+	// queue at once. Defaults to QPS.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+}
+
+// ConfigMapReference points to a ConfigMap.
+type ConfigMapReference struct {
+	// Namespace is the namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ConfigMap.
+	Name string `json:"name"`
+}
+
+// ServiceAccountReference points to a ServiceAccount.
+type ServiceAccountReference struct {
+	// Namespace is the namespace of the ServiceAccount.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ServiceAccount.
+	Name string `json:"name"`
+}
+
+// QueueAccess lists who is entitled to create placement requests for a
+// queue. Users matching any of the entries are allowed.
+type QueueAccess struct {
+	// ServiceAccounts allowed to create placement requests for the queue.
+	// +optional
+	ServiceAccounts []ServiceAccountReference `json:"serviceAccounts,omitempty"`
+
+	// Groups whose members are allowed to create placement requests for
+	// the queue.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
 }
 
 // Queue represents a scheduler queue configuration.
@@ -62,6 +207,15 @@ type Queue struct {
 	// SchedulerName targets placement requests from a specific scheduler (or a profile)
 	SchedulerName string `json:"schedulerName"`
 
+	// Access restricts who can create placement requests for the queue.
+	// If not set anyone allowed to create placement requests can use it.
+	// This is only enforced by the validating webhook, the controller
+	// refuses to start if it is set and the webhook is disabled. The
+	// webhook failure policy must be Fail, otherwise requests go through
+	// unchecked when the webhook is unavailable.
+	// +optional
+	Access *QueueAccess `json:"access,omitempty"`
+
 	// Weight determines how often a scheduler's placement requests get reconciled
 	// compared to other schedulers
 	Weight uint `json:"weight"`
@@ -70,6 +224,41 @@ type Queue struct {
 	// I.e. how many pod-to-node assignments can be listed in a placement request.
 	MaxSize uint `json:"maxSize"`
 
+	// BindingsPerSecond caps the amount of bindings served out of the
+	// queue, regardless of how idle the other queues are. Zero means no
+	// limit.
+	// +optional
+	BindingsPerSecond int32 `json:"bindingsPerSecond,omitempty"`
+
+	// Burst is the maximum amount of bindings served out of the queue at
+	// once. Defaults to BindingsPerSecond. Placement requests with more
+	// bindings than the burst are let through once the bucket is full.
+	// +optional
+	Burst int32 `json:"burst,omitempty"`
+
+	// Tier is the name of the tier the queue belongs to, it must be one of
+	// the tiers listed in the configuration.
+	// +optional
+	Tier string `json:"tier,omitempty"`
+
+	// Quantum is the amount of bindings the queue is granted on each round
+	// of the DeficitRoundRobin algorithm. If not set it is derived from the
+	// queue weight. Ignored by other algorithms.
+	// +optional
+	Quantum uint `json:"quantum,omitempty"`
+
+	// Tenancy, if set, splits the queue into one sub-queue per tenant so
+	// a single namespace (or team) can't starve the others sharing the
+	// same scheduler. The fairness algorithm picks a queue first and the
+	// queue then picks a tenant.
+	// +optional
+	Tenancy *Tenancy `json:"tenancy,omitempty"`
+
+	// PriorityAging, if set, raises the priority of the placement requests
+	// as they wait in the queue so low priority ones are not starved.
+	// +optional
+	PriorityAging *PriorityAging `json:"priorityAging,omitempty"`
+
 	// Plugins configures a list of enabled/disabled plugins for a scheduler
 	// E.g. the scheduling framework provides many native plugins. Yet, some
 	// profiles might disable plugins enabled by default. Configuration
@@ -78,6 +267,61 @@ type Queue struct {
 	Plugins Plugins `json:"plugins"`
 }
 
+// MaxWaitAction is what happens to a placement request that has waited in a
+// queue for longer than the maximum wait.
+type MaxWaitAction string
+
+const (
+	// MaxWaitActionServe serves the placement request next.
+	MaxWaitActionServe MaxWaitAction = "Serve"
+
+	// MaxWaitActionReject rejects the placement request with the
+	// QueueTimeout reason.
+	MaxWaitActionReject MaxWaitAction = "Reject"
+)
+
+// PriorityAging configures how the priority of a placement request rises
+// with the time it spends queued.
+type PriorityAging struct {
+	// Rate is the amount of priority a placement request gains for each
+	// Interval spent in the queue.
+	// +optional
+	Rate int32 `json:"rate,omitempty"`
+
+	// Interval is the amount of time a placement request must wait to
+	// gain Rate priority. Required if Rate is set.
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// MaxWait is the maximum amount of time a placement request may wait
+	// in the queue, after it MaxWaitAction is taken.
+	// +optional
+	MaxWait *metav1.Duration `json:"maxWait,omitempty"`
+
+	// MaxWaitAction is either Serve or Reject. Defaults to Serve.
+	// +optional
+	MaxWaitAction MaxWaitAction `json:"maxWaitAction,omitempty"`
+}
+
+// Tenancy configures how the placement requests in a queue are split among
+// tenants. Tenants are served in proportion to their weights.
+type Tenancy struct {
+	// Label is the placement request label holding the tenant name. If
+	// not set placement requests are split by namespace. Placement
+	// requests without the label belong to the same, unnamed, tenant.
+	// +optional
+	Label string `json:"label,omitempty"`
+
+	// DefaultWeight is the weight of the tenants not listed in Weights.
+	// Defaults to 1.
+	// +optional
+	DefaultWeight uint `json:"defaultWeight,omitempty"`
+
+	// Weights holds the weight of individual tenants, by name.
+	// +optional
+	Weights map[string]uint `json:"weights,omitempty"`
+}
+
 // Plugins represents plugin configuration at either cluster or queue level.
 type Plugins struct {
 	// Validate carries a list of enabled/disabled validate extension points
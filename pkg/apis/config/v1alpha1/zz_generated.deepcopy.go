@@ -22,9 +22,26 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Configuration) DeepCopyInto(out *Configuration) {
 	*out = *in
@@ -36,7 +53,32 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FairnessAlgorithmArgs != nil {
+		in, out := &in.FairnessAlgorithmArgs, &out.FairnessAlgorithmArgs
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FairnessState != nil {
+		in, out := &in.FairnessState, &out.FairnessState
+		*out = new(FairnessState)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tiers != nil {
+		in, out := &in.Tiers, &out.Tiers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpressLane != nil {
+		in, out := &in.ExpressLane, &out.ExpressLane
+		*out = new(ExpressLane)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Plugins.DeepCopyInto(&out.Plugins)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -58,6 +100,59 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressLane) DeepCopyInto(out *ExpressLane) {
+	*out = *in
+	if in.MinPriority != nil {
+		in, out := &in.MinPriority, &out.MinPriority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PriorityClassNames != nil {
+		in, out := &in.PriorityClassNames, &out.PriorityClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressLane.
+func (in *ExpressLane) DeepCopy() *ExpressLane {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressLane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FairnessState) DeepCopyInto(out *FairnessState) {
+	*out = *in
+	out.Window = in.Window
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairnessState.
+func (in *FairnessState) DeepCopy() *FairnessState {
+	if in == nil {
+		return nil
+	}
+	out := new(FairnessState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginSet) DeepCopyInto(out *PluginSet) {
 	*out = *in
@@ -101,9 +196,46 @@ func (in *Plugins) DeepCopy() *Plugins {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityAging) DeepCopyInto(out *PriorityAging) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.MaxWait != nil {
+		in, out := &in.MaxWait, &out.MaxWait
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityAging.
+func (in *PriorityAging) DeepCopy() *PriorityAging {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityAging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Queue) DeepCopyInto(out *Queue) {
 	*out = *in
+	if in.Access != nil {
+		in, out := &in.Access, &out.Access
+		*out = new(QueueAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tenancy != nil {
+		in, out := &in.Tenancy, &out.Tenancy
+		*out = new(Tenancy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityAging != nil {
+		in, out := &in.PriorityAging, &out.PriorityAging
+		*out = new(PriorityAging)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Plugins.DeepCopyInto(&out.Plugins)
 	return
 }
@@ -117,3 +249,68 @@ func (in *Queue) DeepCopy() *Queue {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueAccess) DeepCopyInto(out *QueueAccess) {
+	*out = *in
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueAccess.
+func (in *QueueAccess) DeepCopy() *QueueAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountReference.
+func (in *ServiceAccountReference) DeepCopy() *ServiceAccountReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tenancy) DeepCopyInto(out *Tenancy) {
+	*out = *in
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make(map[string]uint, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tenancy.
+func (in *Tenancy) DeepCopy() *Tenancy {
+	if in == nil {
+		return nil
+	}
+	out := new(Tenancy)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -24,6 +24,7 @@ import (
 const (
 	// PlacementRequestPolicyAllOrNothing indicates that either all
 	// bindings in a placement request succeed or none of them must happen.
+	// Pods bound before a failure are evicted by the controller.
 	PlacementRequestPolicyAllOrNothing PlacementRequestPolicy = "AllOrNothing"
 
 	// PlacementRequestPolicyLenient indicates that it is ok if only a few
@@ -58,6 +59,42 @@ const (
 	PlacementRequestResultRejected PlacementRequestResult = "Rejected"
 )
 
+const (
+	// PlacementRequestPhaseQueued indicates that the placement request
+	// has been accepted by the controller and is waiting in its
+	// scheduler queue.
+	PlacementRequestPhaseQueued PlacementRequestPhase = "Queued"
+
+	// PlacementRequestPhaseBinding indicates that the controller has
+	// dequeued the placement request and is processing its bindings.
+	PlacementRequestPhaseBinding PlacementRequestPhase = "Binding"
+
+	// PlacementRequestPhaseCompleted indicates that the controller has
+	// finished with the placement request, the outcome is available in
+	// the status result.
+	PlacementRequestPhaseCompleted PlacementRequestPhase = "Completed"
+)
+
+const (
+	// PlacementRequestConditionQueued is true while the placement request
+	// is waiting in its scheduler queue.
+	PlacementRequestConditionQueued = "Queued"
+
+	// PlacementRequestConditionBinding is true while the controller is
+	// processing the placement request bindings.
+	PlacementRequestConditionBinding = "Binding"
+
+	// PlacementRequestConditionCompleted is true once the controller is
+	// done with the placement request, either because it has processed it
+	// or because it was rejected.
+	PlacementRequestConditionCompleted = "Completed"
+)
+
+// MinTTLSecondsAfterFinished is the shortest time to live a finished
+// placement request can have. The scheduler polls its placement requests
+// every second and must get to read their result before they are deleted.
+const MinTTLSecondsAfterFinished = 5
+
 // PlacementRequestPriority represents the priority for a given placement
 // request. The higher this value the higher the priority.
 type PlacementRequestPriority int
@@ -70,6 +107,10 @@ type PlacementRequestPolicy string
 // a given placement request.
 type PlacementRequestResult string
 
+// PlacementRequestPhase represents the stage of its lifecycle a placement
+// request is in.
+type PlacementRequestPhase string
+
 // PlacementRequestSpec holds the desired state for a placement request,
 // indicating its policy and also a group of bindings.
 type PlacementRequestSpec struct {
@@ -100,6 +141,14 @@ type PlacementRequestSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinItems=1
 	Bindings []Binding `json:"bindings" protobuf:"bytes,4,rep,name=bindings"`
+
+	// TTLSecondsAfterFinished overrides, for this placement request, the
+	// amount of seconds after which the controller deletes the placement
+	// request once it has finished. It can't be lower than five seconds so
+	// the scheduler gets to read the result before it is deleted.
+	// +optional
+	// +kubebuilder:validation:Minimum=5
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty" protobuf:"varint,5,opt,name=ttlSecondsAfterFinished"`
 }
 
 // Binding represents a binding request for a pod to a node. It contains
@@ -147,6 +196,51 @@ type PlacementRequestStatus struct {
 	//
 	// +listType=atomic
 	Bindings []PlacementRequestBindingResult `json:"bindings,omitempty" protobuf:"bytes,4,rep,name=bindings"`
+
+	// Retries is the number of times the controller had to retry calls to
+	// the API server due to transient errors (throttling, timeouts, server
+	// errors) while processing the placement request.
+	// +optional
+	Retries int32 `json:"retries,omitempty" protobuf:"varint,5,opt,name=retries"`
+
+	// Phase is the stage of its lifecycle the placement request is in.
+	// +optional
+	// +kubebuilder:validation:Enum=Queued;Binding;Completed
+	Phase PlacementRequestPhase `json:"phase,omitempty" protobuf:"bytes,6,opt,name=phase,casttype=PlacementRequestPhase"`
+
+	// Conditions holds the latest observations of the placement request
+	// state. Known condition types are Queued, Binding and Completed.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" protobuf:"bytes,7,rep,name=conditions"`
+
+	// QueuedAt is the time the placement request was last added to its
+	// scheduler queue.
+	// +optional
+	QueuedAt *metav1.Time `json:"queuedAt,omitempty" protobuf:"bytes,8,opt,name=queuedAt"`
+
+	// StartedAt is the time the controller started processing the
+	// placement request bindings.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty" protobuf:"bytes,9,opt,name=startedAt"`
+
+	// CompletedAt is the time the controller finished with the placement
+	// request.
+	// +optional
+	CompletedAt *metav1.Time `json:"completedAt,omitempty" protobuf:"bytes,10,opt,name=completedAt"`
+
+	// ObservedGeneration is the placement request generation the status
+	// refers to.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" protobuf:"varint,11,opt,name=observedGeneration"`
+
+	// Requeues is the number of times the placement request was put back
+	// in its queue after running out of retries. Once it goes over the
+	// controller retry budget the placement request fails.
+	// +optional
+	Requeues int32 `json:"requeues,omitempty" protobuf:"varint,12,opt,name=requeues"`
 }
 
 // PlacementRequestBindingResult holds the result of a single binding
@@ -162,6 +256,10 @@ type PlacementRequestBindingResult struct {
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Scheduler",type=string,JSONPath=`.spec.schedulerName`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Result",type=string,JSONPath=`.status.result`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // PlacementRequest is a pod placement request sent to the placement request
 // controller by a scheduler.
@@ -55,6 +55,7 @@ func internalToV1alpha1(in, out interface{}, s conversion.Scope) error {
 	inArgs := in.(*PlacementRequestBinderArgs)
 	outArgs := out.(*v1alpha1.PlacementRequestBinderArgs)
 	outArgs.Timeout = inArgs.Timeout
+	outArgs.UsePodPriority = inArgs.UsePodPriority
 	return nil
 }
 
@@ -64,6 +65,7 @@ func v1alpha1ToInternal(in, out interface{}, s conversion.Scope) error {
 	inArgs := in.(*v1alpha1.PlacementRequestBinderArgs)
 	outArgs := out.(*PlacementRequestBinderArgs)
 	outArgs.Timeout = inArgs.Timeout
+	outArgs.UsePodPriority = inArgs.UsePodPriority
 	return nil
 }
 
@@ -30,4 +30,8 @@ type PlacementRequestBinderArgs struct {
 	// Timeout defines how long the scheduler waits until it gives up on a
 	// placement request.
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// UsePodPriority makes the plugin set the placement request priority
+	// to the priority of the pod, as resolved from its PriorityClass.
+	UsePodPriority bool `json:"usePodPriority,omitempty"`
 }
@@ -17,21 +17,140 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+	"kombiner/pkg/validator"
 )
 
 var (
 	queuesPath = field.NewPath("queues")
+	tiersPath  = field.NewPath("tiers")
+
+	fairnessAlgorithmPath = field.NewPath("fairnessAlgorithm")
 
-	nonEmptyErrStr              = "must be non-empty"
-	mustBePositiveIntegerErrStr = "must be a positive integer"
+	nonEmptyErrStr                 = "must be non-empty"
+	mustBePositiveIntegerErrStr    = "must be a positive integer"
+	mustBeNonNegativeIntegerErrStr = "must be a non-negative integer"
+	mustBePositiveDurationErrStr   = "must be a positive duration"
+	mustBeAtLeastMinTTLErrStr      = fmt.Sprintf("must be at least %d", v1alpha1.MinTTLSecondsAfterFinished)
 )
 
 func validate(c *configapi.Configuration) field.ErrorList {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, validateQueues(c)...)
+	allErrs = append(allErrs, validateFairnessAlgorithm(c)...)
+	allErrs = append(allErrs, validateTTL(c)...)
+	allErrs = append(allErrs, validateTiers(c)...)
+	allErrs = append(allErrs, validateFairnessState(c)...)
+	allErrs = append(allErrs, validateExpressLane(c)...)
+	allErrs = append(allErrs, validatePlugins(c.Plugins, field.NewPath("plugins"))...)
+	return allErrs
+}
+
+func validateFairnessAlgorithm(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.FairnessAlgorithm == "" {
+		return allErrs
+	}
+
+	if _, ok := queue.Lookup(c.FairnessAlgorithm); !ok {
+		var registered []string
+		for _, name := range queue.Registered() {
+			registered = append(registered, string(name))
+		}
+		allErrs = append(allErrs, field.NotSupported(fairnessAlgorithmPath, c.FairnessAlgorithm, registered))
+	}
+
+	return allErrs
+}
+
+func validateTiers(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+
+	tiers := sets.New[string]()
+	for idx, tier := range c.Tiers {
+		if tier == "" {
+			allErrs = append(allErrs, field.Required(tiersPath.Index(idx), nonEmptyErrStr))
+			continue
+		}
+		if tiers.Has(tier) {
+			allErrs = append(allErrs, field.Duplicate(tiersPath.Index(idx), tier))
+		}
+		tiers.Insert(tier)
+	}
+
+	for idx, queue := range c.Queues {
+		if queue.Tier != "" && !tiers.Has(queue.Tier) {
+			allErrs = append(allErrs, field.NotSupported(queuesPath.Index(idx).Child("tier"), queue.Tier, c.Tiers))
+		}
+	}
+
+	return allErrs
+}
+
+func validateFairnessState(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.FairnessState == nil {
+		return allErrs
+	}
+
+	path := field.NewPath("fairnessState")
+	if c.FairnessState.Window.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("window"), c.FairnessState.Window, mustBePositiveDurationErrStr))
+	}
+
+	if cm := c.FairnessState.ConfigMap; cm != nil {
+		if cm.Namespace == "" {
+			allErrs = append(allErrs, field.Required(path.Child("configMap", "namespace"), nonEmptyErrStr))
+		}
+		if cm.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("configMap", "name"), nonEmptyErrStr))
+		}
+	}
+
+	return allErrs
+}
+
+func validateExpressLane(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.ExpressLane == nil {
+		return allErrs
+	}
+
+	path := field.NewPath("expressLane")
+	if c.ExpressLane.QPS < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("qps"), c.ExpressLane.QPS, mustBeNonNegativeIntegerErrStr))
+	}
+	if c.ExpressLane.Burst < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("burst"), c.ExpressLane.Burst, mustBeNonNegativeIntegerErrStr))
+	}
+	for idx, ns := range c.ExpressLane.Namespaces {
+		if ns == "" {
+			allErrs = append(allErrs, field.Required(path.Child("namespaces").Index(idx), nonEmptyErrStr))
+		}
+	}
+	for idx, name := range c.ExpressLane.PriorityClassNames {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("priorityClassNames").Index(idx), nonEmptyErrStr))
+		}
+	}
+
+	return allErrs
+}
+
+func validateTTL(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.TTLSecondsAfterFinished != nil && *c.TTLSecondsAfterFinished < v1alpha1.MinTTLSecondsAfterFinished {
+		path := field.NewPath("ttlSecondsAfterFinished")
+		allErrs = append(allErrs, field.Invalid(path, *c.TTLSecondsAfterFinished, mustBeAtLeastMinTTLErrStr))
+	}
 	return allErrs
 }
 
@@ -52,6 +171,129 @@ func validateQueues(c *configapi.Configuration) field.ErrorList {
 		if queue.MaxSize < 1 {
 			allErrs = append(allErrs, field.Invalid(queuesPath.Index(idx).Child("maxSize"), queue.MaxSize, mustBePositiveIntegerErrStr))
 		}
+		allErrs = append(allErrs, validateTenancy(queue.Tenancy, queuesPath.Index(idx).Child("tenancy"))...)
+		allErrs = append(allErrs, validatePriorityAging(queue.PriorityAging, queuesPath.Index(idx).Child("priorityAging"))...)
+		allErrs = append(allErrs, validateRateLimit(queue, queuesPath.Index(idx))...)
+		allErrs = append(allErrs, validateQueueAccess(queue.Access, queuesPath.Index(idx).Child("access"))...)
+		allErrs = append(allErrs, validatePlugins(queue.Plugins, queuesPath.Index(idx).Child("plugins"))...)
+	}
+
+	return allErrs
+}
+
+func validateQueueAccess(access *configapi.QueueAccess, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if access == nil {
+		return allErrs
+	}
+
+	for idx, sa := range access.ServiceAccounts {
+		if sa.Namespace == "" {
+			allErrs = append(allErrs, field.Required(path.Child("serviceAccounts").Index(idx).Child("namespace"), nonEmptyErrStr))
+		}
+		if sa.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("serviceAccounts").Index(idx).Child("name"), nonEmptyErrStr))
+		}
+	}
+
+	for idx, group := range access.Groups {
+		if group == "" {
+			allErrs = append(allErrs, field.Required(path.Child("groups").Index(idx), nonEmptyErrStr))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePlugins makes sure only known validate plugins are referred to and
+// that no plugin is listed twice, either in the same list or as enabled and
+// disabled at the same time.
+func validatePlugins(plugins configapi.Plugins, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	known := sets.New(validator.FilterPlugins...)
+	seen := sets.New[string]()
+	lists := map[string][]string{
+		"enabled":  plugins.Validate.Enabled,
+		"disabled": plugins.Validate.Disabled,
+	}
+	for _, list := range []string{"enabled", "disabled"} {
+		listPath := path.Child("validate", list)
+		for idx, name := range lists[list] {
+			if !known.Has(name) {
+				allErrs = append(allErrs, field.NotSupported(listPath.Index(idx), name, validator.FilterPlugins))
+				continue
+			}
+			if seen.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(listPath.Index(idx), name))
+			}
+			seen.Insert(name)
+		}
+	}
+
+	return allErrs
+}
+
+func validateRateLimit(queue configapi.Queue, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if queue.BindingsPerSecond < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("bindingsPerSecond"), queue.BindingsPerSecond, mustBeNonNegativeIntegerErrStr))
+	}
+	if queue.Burst < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("burst"), queue.Burst, mustBeNonNegativeIntegerErrStr))
+	}
+	if queue.Burst > 0 && queue.BindingsPerSecond <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("burst"), queue.Burst, "requires bindingsPerSecond to be set"))
+	}
+
+	return allErrs
+}
+
+func validateTenancy(tenancy *configapi.Tenancy, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if tenancy == nil {
+		return allErrs
+	}
+
+	if tenancy.Label != "" {
+		for _, msg := range validation.IsQualifiedName(tenancy.Label) {
+			allErrs = append(allErrs, field.Invalid(path.Child("label"), tenancy.Label, msg))
+		}
+	}
+
+	for tenant, weight := range tenancy.Weights {
+		if weight < 1 {
+			allErrs = append(allErrs, field.Invalid(path.Child("weights").Key(tenant), weight, mustBePositiveIntegerErrStr))
+		}
+	}
+
+	return allErrs
+}
+
+func validatePriorityAging(aging *configapi.PriorityAging, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if aging == nil {
+		return allErrs
+	}
+
+	if aging.Rate < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("rate"), aging.Rate, mustBeNonNegativeIntegerErrStr))
+	}
+	if aging.Rate > 0 && aging.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("interval"), aging.Interval, mustBePositiveDurationErrStr))
+	}
+	if aging.MaxWait != nil && aging.MaxWait.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxWait"), aging.MaxWait, mustBePositiveDurationErrStr))
+	}
+
+	switch aging.MaxWaitAction {
+	case "", configapi.MaxWaitActionServe, configapi.MaxWaitActionReject:
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			path.Child("maxWaitAction"), aging.MaxWaitAction,
+			[]configapi.MaxWaitAction{configapi.MaxWaitActionServe, configapi.MaxWaitActionReject},
+		))
 	}
 
 	return allErrs
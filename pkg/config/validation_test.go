@@ -18,12 +18,15 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
 
 	configapi "kombiner/pkg/apis/config/v1alpha1"
 )
@@ -92,11 +95,343 @@ func TestValidate(t *testing.T) {
 				field.Invalid(field.NewPath("queues").Index(0).Child("maxSize"), "", mustBePositiveIntegerErrStr),
 			},
 		},
+		"negative ttl after finished": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+				TTLSecondsAfterFinished: ptr.To[int32](-1),
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("ttlSecondsAfterFinished"), "", mustBeAtLeastMinTTLErrStr),
+			},
+		},
+		"ttl after finished shorter than the scheduler poll": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+				TTLSecondsAfterFinished: ptr.To[int32](0),
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("ttlSecondsAfterFinished"), "", mustBeAtLeastMinTTLErrStr),
+			},
+		},
+		"minimum ttl after finished": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+				TTLSecondsAfterFinished: ptr.To[int32](5),
+			},
+		},
+		"invalid queue tenancy": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Tenancy: &configapi.Tenancy{
+							Label:   "not a label",
+							Weights: map[string]uint{"team-a": 0},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("queues").Index(0).Child("tenancy", "label"), "", ""),
+				field.Invalid(field.NewPath("queues").Index(0).Child("tenancy", "weights").Key("team-a"), "", mustBePositiveIntegerErrStr),
+			},
+		},
+		"valid queue tenancy": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Tenancy: &configapi.Tenancy{
+							Label:         "example.com/team",
+							DefaultWeight: 1,
+							Weights:       map[string]uint{"team-a": 3},
+						},
+					},
+				},
+			},
+		},
+		"invalid queue priority aging": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						PriorityAging: &configapi.PriorityAging{
+							Rate:          1,
+							MaxWait:       &metav1.Duration{},
+							MaxWaitAction: "Ignore",
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("queues").Index(0).Child("priorityAging", "interval"), "", mustBePositiveDurationErrStr),
+				field.Invalid(field.NewPath("queues").Index(0).Child("priorityAging", "maxWait"), "", mustBePositiveDurationErrStr),
+				field.NotSupported(field.NewPath("queues").Index(0).Child("priorityAging", "maxWaitAction"), "", []string{}),
+			},
+		},
+		"valid queue priority aging": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						PriorityAging: &configapi.PriorityAging{
+							Rate:          1,
+							Interval:      metav1.Duration{Duration: time.Minute},
+							MaxWait:       &metav1.Duration{Duration: time.Hour},
+							MaxWaitAction: configapi.MaxWaitActionReject,
+						},
+					},
+				},
+			},
+		},
+		"invalid queue rate limit": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName:     "default-scheduler",
+						Weight:            1,
+						MaxSize:           1,
+						BindingsPerSecond: -1,
+					},
+					{
+						SchedulerName: "batch-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Burst:         5,
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("queues").Index(0).Child("bindingsPerSecond"), "", mustBeNonNegativeIntegerErrStr),
+				field.Invalid(field.NewPath("queues").Index(1).Child("burst"), "", ""),
+			},
+		},
+		"valid queue rate limit": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName:     "default-scheduler",
+						Weight:            1,
+						MaxSize:           1,
+						BindingsPerSecond: 50,
+						Burst:             100,
+					},
+				},
+			},
+		},
+		"invalid queue access": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Access: &configapi.QueueAccess{
+							ServiceAccounts: []configapi.ServiceAccountReference{{Name: "scheduler"}},
+							Groups:          []string{""},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Required(field.NewPath("queues").Index(0).Child("access", "serviceAccounts").Index(0).Child("namespace"), nonEmptyErrStr),
+				field.Required(field.NewPath("queues").Index(0).Child("access", "groups").Index(0), nonEmptyErrStr),
+			},
+		},
+		"valid queue access": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Access: &configapi.QueueAccess{
+							ServiceAccounts: []configapi.ServiceAccountReference{{Namespace: "kube-system", Name: "scheduler"}},
+							Groups:          []string{"schedulers"},
+						},
+					},
+				},
+			},
+		},
+		"invalid express lane": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+				ExpressLane: &configapi.ExpressLane{
+					Namespaces: []string{"kube-system", ""},
+					QPS:        -1,
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("expressLane", "qps"), "", mustBeNonNegativeIntegerErrStr),
+				field.Required(field.NewPath("expressLane", "namespaces").Index(1), nonEmptyErrStr),
+			},
+		},
+		"unknown fairness algorithm": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+				FairnessAlgorithm: "Lottery",
+			},
+			wantErr: field.ErrorList{
+				field.NotSupported(field.NewPath("fairnessAlgorithm"), "", []string{}),
+			},
+		},
+		"registered fairness algorithm": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+				FairnessAlgorithm: configapi.DominantResource,
+			},
+		},
+		"unknown queue tier": {
+			cfg: &configapi.Configuration{
+				Tiers: []string{"system", "batch"},
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Tier:          "critical",
+					},
+					{
+						SchedulerName: "batch-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Tier:          "batch",
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.NotSupported(field.NewPath("queues").Index(0).Child("tier"), "critical", []string{"system", "batch"}),
+			},
+		},
+		"duplicated and empty tiers": {
+			cfg: &configapi.Configuration{
+				Tiers: []string{"system", "", "system"},
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Required(field.NewPath("tiers").Index(1), nonEmptyErrStr),
+				field.Duplicate(field.NewPath("tiers").Index(2), "system"),
+			},
+		},
+		"invalid fairness state": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+					},
+				},
+				FairnessState: &configapi.FairnessState{
+					ConfigMap: &configapi.ConfigMapReference{Namespace: "kube-system"},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("fairnessState", "window"), "", mustBePositiveDurationErrStr),
+				field.Required(field.NewPath("fairnessState", "configMap", "name"), nonEmptyErrStr),
+			},
+		},
+		"invalid validate plugins": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Plugins: configapi.Plugins{
+							Validate: configapi.PluginSet{
+								Enabled:  []string{"TaintToleration", "TaintToleration"},
+								Disabled: []string{"TaintToleration"},
+							},
+						},
+					},
+				},
+				Plugins: configapi.Plugins{
+					Validate: configapi.PluginSet{
+						Enabled: []string{"PrioritySort"},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				field.Duplicate(field.NewPath("queues").Index(0).Child("plugins", "validate", "enabled").Index(1), ""),
+				field.Duplicate(field.NewPath("queues").Index(0).Child("plugins", "validate", "disabled").Index(0), ""),
+				field.NotSupported(field.NewPath("plugins", "validate", "enabled").Index(0), "", []string{}),
+			},
+		},
+		"valid validate plugins": {
+			cfg: &configapi.Configuration{
+				Queues: []configapi.Queue{
+					{
+						SchedulerName: "default-scheduler",
+						Weight:        1,
+						MaxSize:       1,
+						Plugins: configapi.Plugins{
+							Validate: configapi.PluginSet{
+								Enabled:  []string{"PodTopologySpread"},
+								Disabled: []string{"TaintToleration"},
+							},
+						},
+					},
+				},
+				Plugins: configapi.Plugins{
+					Validate: configapi.PluginSet{
+						Enabled: []string{"TaintToleration", "NodeResourcesFit"},
+					},
+				},
+			},
+		},
 		// TODO(ingvagabund):
 		// more tests:
-		// - no duplicates in enabled/disabled list of plugins (for both queue based and cluster wide)
-		// - no intersection of plugin names in enabled and disabled lists (for both queue based and cluster wide)
-		// - enabled/disabled plugins must be known names (for both queue based and cluster wide)
 		// - no two queues have the scheduler schedulerName
 	}
 
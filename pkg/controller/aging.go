@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"kombiner/pkg/queue"
+)
+
+// ReasonQueueTimeout is the reason set on PlacementRequests rejected because
+// they waited in their queue for longer than allowed.
+const ReasonQueueTimeout = "QueueTimeout"
+
+// RejectExpired rejects the PlacementRequests that have been waiting in their
+// queues for longer than the configured maximum wait. Only queues configured
+// to reject these PlacementRequests are affected, others serve them next.
+func (controller *PlacementRequestController) RejectExpired(_ context.Context) {
+	for _, qcfg := range controller.queues {
+		for _, pr := range qcfg.QueueRef.PopExpired() {
+			prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+			controller.logger.V(3).Info("placement request waited for too long", "obj", prid)
+			msg := "Placement request waited in the queue for longer than allowed"
+			controller.TryToRejectPlacementRequest(pr, ReasonQueueTimeout, msg)
+		}
+	}
+}
+
+// QueuesDump is the content of the queues as returned by the DebugHandler.
+type QueuesDump struct {
+	Queues  map[string][]queue.QueuedPlacementRequest `json:"queues"`
+	Express []queue.QueuedPlacementRequest            `json:"express,omitempty"`
+}
+
+// DebugHandler returns an http handler that dumps the content of the queues
+// as json, including the effective priority of each PlacementRequest. This
+// is useful when diagnosing starvation.
+func (controller *PlacementRequestController) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		dump := QueuesDump{Queues: map[string][]queue.QueuedPlacementRequest{}}
+		for name, qcfg := range controller.queues {
+			dump.Queues[name] = qcfg.QueueRef.Dump()
+		}
+		if controller.express != nil {
+			dump.Express = controller.express.Dump()
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(dump); err != nil {
+			controller.logger.Error(err, "failed to write queues dump")
+		}
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+)
+
+func TestRejectExpired(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pr.Status.QueuedAt = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+
+	controller, _ := newTestController(t, pr, nil, nil)
+	prqueue := queue.NewPlacementRequestQueue(
+		queue.WithAging(&queue.Aging{MaxWait: time.Minute, Reject: true}),
+	)
+	controller.queues = map[string]queue.QueueConfig{
+		"scheduler": {
+			Queue:    configapi.Queue{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+			QueueRef: prqueue,
+		},
+	}
+	prqueue.Push(pr)
+
+	controller.RejectExpired(context.Background())
+	require.Zero(prqueue.Len())
+
+	current := stored(t, controller, pr)
+	require.Equal(v1alpha1.PlacementRequestResultRejected, current.Status.Result)
+	require.Equal(ReasonQueueTimeout, current.Status.Reason)
+}
+
+func TestDebugHandler(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pr.Spec.Priority = 5
+
+	controller, _ := newTestController(t, pr, nil, nil)
+	prqueue := queue.NewPlacementRequestQueue()
+	controller.queues = map[string]queue.QueueConfig{
+		"scheduler": {
+			Queue:    configapi.Queue{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+			QueueRef: prqueue,
+		},
+	}
+	prqueue.Push(pr)
+
+	recorder := httptest.NewRecorder()
+	controller.DebugHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/queues", nil))
+	require.Equal(http.StatusOK, recorder.Code)
+
+	var dump QueuesDump
+	require.NoError(json.Unmarshal(recorder.Body.Bytes(), &dump))
+	require.Len(dump.Queues["scheduler"], 1)
+	require.Equal("ns/pr", dump.Queues["scheduler"][0].Key)
+	require.Equal(int64(5), dump.Queues["scheduler"][0].EffectivePriority)
+	require.Empty(dump.Express)
+}
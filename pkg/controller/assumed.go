@@ -0,0 +1,230 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	resourcehelper "k8s.io/component-helpers/resource"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// ReasonInsufficientResources is the reason set on bindings whose target node
+// does not have enough room left for the pod.
+const ReasonInsufficientResources = "InsufficientResources"
+
+// podNodeNameIndex is the name of the pod informer index on spec.nodeName.
+const podNodeNameIndex = "spec.nodeName"
+
+// indexPodByNodeName indexes pods by the node they are bound to. Pods not yet
+// bound are not indexed.
+func indexPodByNodeName(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return nil, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
+// assumedPods keeps track of the pods we have bound but that the informer has
+// not yet seen bound. Like the scheduler cache does with its assumed pods we
+// account for them against the capacity of their nodes until the informer
+// catches up.
+type assumedPods struct {
+	mtx  sync.Mutex
+	pods map[types.UID]*corev1.Pod
+}
+
+// newAssumedPods returns an empty assumed pods tracker.
+func newAssumedPods() *assumedPods {
+	return &assumedPods{pods: map[types.UID]*corev1.Pod{}}
+}
+
+// assume records the pod as bound to the provided node.
+func (a *assumedPods) assume(pod *corev1.Pod, nodeName string) {
+	assumed := pod.DeepCopy()
+	assumed.Spec.NodeName = nodeName
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	a.pods[pod.UID] = assumed
+}
+
+// forget stops accounting for the pod.
+func (a *assumedPods) forget(uid types.UID) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	delete(a.pods, uid)
+}
+
+// list returns the assumed pods the informer hasn't seen bound yet. Pods the
+// informer already sees bound, or that don't exist anymore, are forgotten.
+func (a *assumedPods) list(podlister corev1listers.PodLister) []*corev1.Pod {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	var pending []*corev1.Pod
+	for uid, pod := range a.pods {
+		current, err := podlister.Pods(pod.Namespace).Get(pod.Name)
+		if err != nil || current.UID != uid || current.Spec.NodeName != "" {
+			delete(a.pods, uid)
+			continue
+		}
+		pending = append(pending, pod)
+	}
+	return pending
+}
+
+// nodeCapacity holds the resources left on the nodes targeted by a placement
+// request. The inflight tracker makes sure nobody else binds to these nodes
+// while the placement request is processed, the bindings of the placement
+// request itself may be processed concurrently though.
+type nodeCapacity struct {
+	mtx  sync.Mutex
+	free map[string]corev1.ResourceList
+}
+
+// newNodeCapacity computes the resources left on each node targeted by the
+// placement request: the node allocatable minus the requests of the pods
+// bound to it, as seen by the informer or assumed. Nodes that can't be found
+// are not accounted for, their bindings are going to fail verification. Only
+// the pods bound to the target nodes are looked at, through the node name
+// index.
+func (controller *PlacementRequestController) newNodeCapacity(pr *v1alpha1.PlacementRequest) *nodeCapacity {
+	capacity := &nodeCapacity{free: map[string]corev1.ResourceList{}}
+	for _, binding := range pr.Spec.Bindings {
+		if node, err := controller.nodelister.Get(binding.NodeName); err == nil {
+			capacity.free[node.Name] = node.Status.Allocatable.DeepCopy()
+		}
+	}
+
+	pods := controller.assumed.list(controller.podlister)
+	for name := range capacity.free {
+		objs, err := controller.podindexer.ByIndex(podNodeNameIndex, name)
+		if err != nil {
+			// without knowing what is running we can't tell if the
+			// pods fit, we let the kubelet be the judge.
+			controller.logger.Error(err, "failed to list pods, skipping resource accounting")
+			return &nodeCapacity{free: map[string]corev1.ResourceList{}}
+		}
+		for _, obj := range objs {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				pods = append(pods, pod)
+			}
+		}
+	}
+
+	for _, pod := range pods {
+		free, ok := capacity.free[pod.Spec.NodeName]
+		if !ok || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for name, quantity := range podRequests(pod) {
+			current := free[name]
+			current.Sub(quantity)
+			free[name] = current
+		}
+	}
+	return capacity
+}
+
+// reserve takes the pod requests from the node. If the node does not have
+// room for the pod a BindingError listing the missing resources is returned.
+// Nodes we know nothing about are not checked.
+func (c *nodeCapacity) reserve(pod *corev1.Pod, nodeName string) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	free, ok := c.free[nodeName]
+	if !ok {
+		return nil
+	}
+
+	requests := podRequests(pod)
+	var missing []string
+	for name, quantity := range requests {
+		available := free[name]
+		if quantity.Cmp(available) > 0 {
+			missing = append(
+				missing,
+				fmt.Sprintf("%s (requested %s, available %s)", name, quantity.String(), available.String()),
+			)
+		}
+	}
+
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		message := fmt.Sprintf("Node %s has insufficient %s", nodeName, strings.Join(missing, ", "))
+		return &BindingError{Reason: ReasonInsufficientResources, Message: message}
+	}
+
+	for name, quantity := range requests {
+		current := free[name]
+		current.Sub(quantity)
+		free[name] = current
+	}
+	return nil
+}
+
+// release gives the pod requests back to the node.
+func (c *nodeCapacity) release(pod *corev1.Pod, nodeName string) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	free, ok := c.free[nodeName]
+	if !ok {
+		return
+	}
+
+	for name, quantity := range podRequests(pod) {
+		current := free[name]
+		current.Add(quantity)
+		free[name] = current
+	}
+}
+
+// podRequests returns the resources requested by the pod, zeroed requests are
+// left out. The pod also takes one of the node pod slots.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{corev1.ResourcePods: *resource.NewQuantity(1, resource.DecimalSI)}
+	for name, quantity := range resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{}) {
+		if !quantity.IsZero() {
+			requests[name] = quantity
+		}
+	}
+	return requests
+}
+
+// reserve takes the requests of the binding pod from the capacity of its
+// target node. The pod is returned so it can be assumed once bound.
+func (controller *PlacementRequestController) reserve(
+	capacity *nodeCapacity, namespace string, binding v1alpha1.Binding,
+) (*corev1.Pod, error) {
+	pod, err := controller.podlister.Pods(namespace).Get(binding.PodName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod %s: %w", binding.PodName, err)
+	}
+	return pod, capacity.reserve(pod, binding.NodeName)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// cpuPod returns a test pod requesting the provided amount of cpu.
+func cpuPod(name, cpu string) *corev1.Pod {
+	pod := testPod(name)
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name: "main",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		},
+	}
+	return pod
+}
+
+// cpuNode returns a test node with the provided amount of allocatable cpu.
+func cpuNode(name, cpu string) *corev1.Node {
+	node := testNode(name)
+	node.Status.Allocatable[corev1.ResourceCPU] = resource.MustParse(cpu)
+	return node
+}
+
+func TestScheduleOneLenientInsufficientResources(t *testing.T) {
+	require := require.New(t)
+
+	// one of the two cpus is taken by a running pod, finished pods do not
+	// count.
+	running := cpuPod("running", "1")
+	running.Spec.NodeName = "node"
+	finished := cpuPod("finished", "2")
+	finished.Spec.NodeName = "node"
+	finished.Status.Phase = corev1.PodSucceeded
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b")
+	pods := []*corev1.Pod{running, finished, cpuPod("a", "1"), cpuPod("b", "1")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{cpuNode("node", "2")})
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Equal([]string{"a"}, actions(kubecli, "binding"))
+	require.Equal(
+		map[string]string{
+			"a": "Binding successful",
+			"b": ReasonInsufficientResources,
+		},
+		results(pr),
+	)
+
+	for _, binding := range pr.Status.Bindings {
+		if binding.Binding.PodName == "b" {
+			require.Contains(binding.Message, "cpu (requested 1, available 0)")
+		}
+	}
+}
+
+func TestScheduleOneAllOrNothingInsufficientResources(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyAllOrNothing, "a", "b")
+	pods := []*corev1.Pod{cpuPod("a", "2"), cpuPod("b", "2")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{cpuNode("node", "3")})
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Empty(actions(kubecli, "binding"), "no pod should have been bound")
+	require.Equal(v1alpha1.PlacementRequestResultFailure, pr.Status.Result)
+	require.Equal(
+		map[string]string{
+			"a": "Binding not attempted",
+			"b": ReasonInsufficientResources,
+		},
+		results(pr),
+	)
+}
+
+func TestScheduleOneAccountsAssumedPods(t *testing.T) {
+	require := require.New(t)
+
+	// the informer never catches up in this test so the pod bound by the
+	// first placement request is only known to us as assumed.
+	first := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pods := []*corev1.Pod{cpuPod("a", "1"), cpuPod("b", "1")}
+	controller, kubecli := newTestController(t, first, pods, []*corev1.Node{cpuNode("node", "1")})
+
+	require.NoError(controller.ScheduleOne(context.Background(), first))
+	require.Equal(map[string]string{"a": "Binding successful"}, results(stored(t, controller, first)))
+
+	second := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "b")
+	second.Name = "second"
+	_, err := controller.client.KombinerV1alpha1().PlacementRequests(second.Namespace).Create(
+		context.Background(), second, metav1.CreateOptions{},
+	)
+	require.NoError(err)
+
+	require.NoError(controller.ScheduleOne(context.Background(), second))
+	require.Equal([]string{"a"}, actions(kubecli, "binding"))
+	require.Equal(map[string]string{"b": ReasonInsufficientResources}, results(stored(t, controller, second)))
+}
+
+func TestAssumedPodsList(t *testing.T) {
+	require := require.New(t)
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	podlister := corev1listers.NewPodLister(indexer)
+
+	pending, bound, recreated := testPod("pending"), testPod("bound"), testPod("recreated")
+	for _, pod := range []*corev1.Pod{pending, bound, recreated} {
+		require.NoError(indexer.Add(pod))
+	}
+
+	assumed := newAssumedPods()
+	for _, pod := range []*corev1.Pod{pending, bound, recreated, testPod("deleted")} {
+		assumed.assume(pod, "node")
+	}
+
+	// once the informer sees the pod bound, or the pod is gone, we stop
+	// accounting for it.
+	bound = bound.DeepCopy()
+	bound.Spec.NodeName = "node"
+	require.NoError(indexer.Update(bound))
+	recreated = recreated.DeepCopy()
+	recreated.UID = "another"
+	require.NoError(indexer.Update(recreated))
+
+	list := assumed.list(podlister)
+	require.Len(list, 1)
+	require.Equal("pending", list[0].Name)
+	require.Equal("node", list[0].Spec.NodeName)
+	require.Len(assumed.pods, 1)
+
+	assumed.forget(pending.UID)
+	require.Empty(assumed.list(podlister))
+}
+
+func TestNewNodeCapacityTargetNodesOnly(t *testing.T) {
+	require := require.New(t)
+
+	local := cpuPod("local", "1")
+	local.Spec.NodeName = "node"
+	remote := cpuPod("remote", "1")
+	remote.Spec.NodeName = "other"
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pods := []*corev1.Pod{local, remote, cpuPod("a", "1")}
+	nodes := []*corev1.Node{cpuNode("node", "2"), cpuNode("other", "2")}
+	controller, _ := newTestController(t, pr, pods, nodes)
+
+	capacity := controller.newNodeCapacity(pr)
+	require.Len(capacity.free, 1)
+	cpu := capacity.free["node"][corev1.ResourceCPU]
+	require.Equal("1", cpu.String())
+
+	// pods not bound to any node are not indexed.
+	unbound, err := controller.podindexer.ByIndex(podNodeNameIndex, "")
+	require.NoError(err)
+	require.Empty(unbound)
+}
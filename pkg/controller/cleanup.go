@@ -0,0 +1,95 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// Cleanup deletes finished PlacementRequests whose time to live has expired.
+// The time to live comes from the PlacementRequest itself or, if not set
+// there, from the controller configuration. PlacementRequests without a time
+// to live are left alone.
+func (controller *PlacementRequestController) Cleanup(ctx context.Context) {
+	prs, err := controller.prlister.List(labels.Everything())
+	if err != nil {
+		controller.logger.Error(err, "failed to list placement requests for cleanup")
+		return
+	}
+
+	now := time.Now()
+	for _, pr := range prs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		expiration, ok := controller.expiration(pr)
+		if !ok || expiration.After(now) {
+			continue
+		}
+
+		prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+		controller.logger.V(3).Info("deleting expired placement request", "obj", prid)
+
+		// we make sure we are deleting the object we have evaluated and
+		// not a new one with the same name.
+		prqclient := controller.client.KombinerV1alpha1().PlacementRequests(pr.Namespace)
+		opts := metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &pr.UID}}
+		if err := prqclient.Delete(ctx, pr.Name, opts); err != nil && !apierrors.IsNotFound(err) {
+			controller.logger.Error(err, "failed to delete expired placement request", "obj", prid)
+		}
+	}
+}
+
+// expiration returns the time after which the provided PlacementRequest can
+// be deleted. The returned bool is false if the PlacementRequest hasn't
+// finished yet, is already being deleted or has no time to live. The time to
+// live is never shorter than MinTTLSecondsAfterFinished.
+func (controller *PlacementRequestController) expiration(pr *v1alpha1.PlacementRequest) (time.Time, bool) {
+	if pr.DeletionTimestamp != nil || pr.Status.Result == v1alpha1.PlacementRequestResultUnknown {
+		return time.Time{}, false
+	}
+
+	ttl := controller.ttl
+	if pr.Spec.TTLSecondsAfterFinished != nil {
+		ttl = pr.Spec.TTLSecondsAfterFinished
+	}
+	if ttl == nil {
+		return time.Time{}, false
+	}
+
+	// placement requests stored before the minimum was enforced may have
+	// a shorter ttl, the scheduler must still get to read their result.
+	seconds := max(*ttl, v1alpha1.MinTTLSecondsAfterFinished)
+
+	// placement requests finished by older versions of the controller
+	// do not have a completion time, for those the best we can do is
+	// to count from their creation.
+	finished := pr.CreationTimestamp.Time
+	if pr.Status.CompletedAt != nil {
+		finished = pr.Status.CompletedAt.Time
+	}
+
+	return finished.Add(time.Duration(seconds) * time.Second), true
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/generated/clientset/versioned/fake"
+	lister "kombiner/pkg/generated/listers/kombiner/v1alpha1"
+)
+
+func TestCleanup(t *testing.T) {
+	require := require.New(t)
+
+	finished := func(name string, ago time.Duration, ttl *int32) *v1alpha1.PlacementRequest {
+		pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+		pr.Name = name
+		pr.Spec.TTLSecondsAfterFinished = ttl
+		pr.Status.Result = v1alpha1.PlacementRequestResultSuccess
+		pr.Status.CompletedAt = ptr.To(metav1.NewTime(time.Now().Add(-ago)))
+		return pr
+	}
+
+	pending := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pending.Name = "pending"
+	pending.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+
+	prs := []*v1alpha1.PlacementRequest{
+		pending,
+		finished("expired", 2*time.Minute, nil),
+		finished("recent", 30*time.Second, nil),
+		finished("override-expired", 30*time.Second, ptr.To[int32](10)),
+		finished("override-recent", 2*time.Minute, ptr.To[int32](3600)),
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	objs := []runtime.Object{}
+	for _, pr := range prs {
+		require.NoError(indexer.Add(pr))
+		objs = append(objs, pr)
+	}
+
+	controller := &PlacementRequestController{
+		options:  defaultOptions,
+		client:   fake.NewSimpleClientset(objs...),
+		prlister: lister.NewPlacementRequestLister(indexer),
+		ttl:      ptr.To[int32](60),
+	}
+	controller.Cleanup(context.Background())
+
+	list, err := controller.client.KombinerV1alpha1().PlacementRequests("ns").List(
+		context.Background(), metav1.ListOptions{},
+	)
+	require.NoError(err)
+
+	var names []string
+	for _, pr := range list.Items {
+		names = append(names, pr.Name)
+	}
+	require.ElementsMatch([]string{"pending", "recent", "override-recent"}, names)
+}
+
+func TestCleanupWithoutTTL(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pr.Status.Result = v1alpha1.PlacementRequestResultFailure
+	pr.CreationTimestamp = metav1.NewTime(time.Now().Add(-24 * time.Hour))
+
+	controller := &PlacementRequestController{options: defaultOptions}
+	_, ok := controller.expiration(pr)
+	require.False(ok, "without a ttl requests must never expire")
+
+	// without a completion time we count from the creation.
+	pr.Spec.TTLSecondsAfterFinished = ptr.To[int32](60)
+	expiration, ok := controller.expiration(pr)
+	require.True(ok)
+	require.Equal(pr.CreationTimestamp.Add(time.Minute), expiration)
+}
+
+func TestCleanupMinimumTTL(t *testing.T) {
+	require := require.New(t)
+
+	// the scheduler may not have read the result of a request that has
+	// just finished, even if its ttl says it can be deleted.
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pr.Status.Result = v1alpha1.PlacementRequestResultSuccess
+	pr.Status.CompletedAt = ptr.To(metav1.Now())
+	pr.Spec.TTLSecondsAfterFinished = ptr.To[int32](0)
+
+	controller := &PlacementRequestController{options: defaultOptions}
+	expiration, ok := controller.expiration(pr)
+	require.True(ok)
+	require.Equal(pr.Status.CompletedAt.Add(v1alpha1.MinTTLSecondsAfterFinished*time.Second), expiration)
+}
@@ -18,147 +18,775 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 
 	configapi "kombiner/pkg/apis/config/v1alpha1"
 	"kombiner/pkg/apis/kombiner/v1alpha1"
 	client "kombiner/pkg/generated/clientset/versioned"
 	informer "kombiner/pkg/generated/informers/externalversions/kombiner/v1alpha1"
 	lister "kombiner/pkg/generated/listers/kombiner/v1alpha1"
+	"kombiner/pkg/metrics"
 	helpers "kombiner/pkg/placementrequests/v1alpha1"
 	"kombiner/pkg/queue"
+	"kombiner/pkg/validator"
 )
 
+// BindingError is returned when a binding can't be fulfilled. It carries a
+// short reason and a human readable message, both are used when setting the
+// binding result in the placement request status.
+type BindingError struct {
+	Reason  string
+	Message string
+}
+
+// Error returns the error message.
+func (e *BindingError) Error() string {
+	return e.Message
+}
+
 // PlacementRequestController is a controller for handling PlacementRequests.
 type PlacementRequestController struct {
 	options
 
 	prlister   lister.PlacementRequestLister
 	podlister  corev1listers.PodLister
+	podindexer cache.Indexer
+	nodelister corev1listers.NodeLister
 	client     client.Interface
 	coreclient corev1client.CoreV1Interface
 	queues     map[string]queue.QueueConfig
 	iterator   *queue.QueueIterator
+	algorithm  configapi.FairnessAlgorithm
+	inflight   *inflight
+	assumed    *assumedPods
+	ttl        *int32
+
+	fairness          *queue.FairnessState
+	fairnessConfigMap *configapi.ConfigMapReference
+
+	express     *queue.PlacementRequestQueue
+	expressLane *configapi.ExpressLane
+
+	validators map[string]*validator.Validator
+
+	// statuses holds the keys of the placement requests whose status
+	// must be updated after they have been seen by the event handlers.
+	// it is only fed while we are the leader.
+	statuses workqueue.TypedRateLimitingInterface[string]
+	leading  atomic.Bool
+
+	// handlers is held by the event handlers while they touch the queues
+	// and by RebuildQueues while it rebuilds them.
+	handlers sync.Mutex
+
+	allowCordonedNodes bool
 }
 
 // Run reads PlacementRequsts (already sorted by priority and weigth) and calls
 // ScheduleOne for each one of them. This is a blocking function that returns
-// only when the provided context is done. XXX some more error handling is
-// needed here.
+// only when the provided context is done. Before starting we rebuild the
+// queues out of the informer cache so this function expects the caches to
+// be already synced. PlacementRequests are handed over to a pool of workers
+// in the order decided by the fairness algorithm. If a PlacementRequest
+// touches a pod or a node that is being handled by a worker we wait for it
+// to finish before moving on, this way the order is preserved. Finished
+// PlacementRequests are cleaned up in the background and the ones waiting
+// in their queues for too long are rejected. Status updates decided by the
+// event handlers are only written from here, by the status worker. The
+// fairness state, if any, is restored before we start and saved periodically
+// while we are the leader, it is not saved once the context is done. XXX
+// some more error handling is needed here.
 func (controller *PlacementRequestController) Run(ctx context.Context) {
+	controller.leading.Store(true)
+	go controller.runStatusWorker(ctx)
+
+	if err := controller.RebuildQueues(); err != nil {
+		controller.logger.Error(err, "failed to rebuild queues")
+	}
+
+	controller.persistFairnessState(ctx)
+
+	go wait.UntilWithContext(ctx, controller.Cleanup, controller.cleanupInterval)
+	go wait.UntilWithContext(ctx, controller.RejectExpired, controller.queueTimeoutInterval)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	workers := make(chan struct{}, controller.workers)
 	go controller.iterator.Run(ctx)
 	for {
 		select {
-		case pr := <-controller.iterator.Next:
-			if err := controller.ScheduleOne(ctx, pr); err != nil {
-				controller.logger.Error(err, "failed to schedule")
+		case pr, ok := <-controller.iterator.Next:
+			if !ok {
+				return
+			}
+
+			// if we have been asked to stop (e.g. we lost the leader
+			// election) we must not bind anything else.
+			if ctx.Err() != nil {
+				return
+			}
+
+			controller.observePop(pr)
+			keys := conflictKeys(pr)
+			if !controller.inflight.acquire(ctx, keys) {
+				return
+			}
+
+			select {
+			case workers <- struct{}{}:
+			case <-ctx.Done():
+				controller.inflight.release(keys)
+				return
 			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-workers }()
+				defer controller.inflight.release(keys)
+				if err := controller.ScheduleOne(ctx, pr); err != nil {
+					controller.logger.Error(err, "failed to schedule")
+				}
+			}()
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// observePop records the metrics related to a PlacementRequest that has just
+// been served by the fairness algorithm or by the express queue. The wait is
+// measured since the PlacementRequest was queued, the ones the status worker
+// hasn't marked as queued yet use their creation time.
+func (controller *PlacementRequestController) observePop(pr *v1alpha1.PlacementRequest) {
+	queuedAt := pr.CreationTimestamp.Time
+	if pr.Status.QueuedAt != nil {
+		queuedAt = pr.Status.QueuedAt.Time
+	}
+
+	algorithm := string(controller.algorithm)
+	if controller.isExpress(pr) {
+		algorithm = metrics.ExpressAlgorithm
+	}
+
+	scheduler := pr.Spec.SchedulerName
+	metrics.QueueWaitDuration.WithLabelValues(scheduler).Observe(
+		time.Since(queuedAt).Seconds(),
+	)
+	metrics.BindingsServed.WithLabelValues(scheduler, algorithm).Add(
+		float64(len(pr.Spec.Bindings)),
+	)
+}
+
+// RebuildQueues drops everything from the internal queues and enqueues again
+// all PlacementRequests found in the informer cache that are yet to be
+// processed. Our event handlers keep populating the queues even when we are
+// not the leader, when taking over we can't trust them as the previous leader
+// may have processed some of the requests we have queued.
+func (controller *PlacementRequestController) RebuildQueues() error {
+	controller.handlers.Lock()
+	defer controller.handlers.Unlock()
+
+	// the queues are cleared before listing. a request added to the cache
+	// after the list is pushed by the event handlers once we are done.
+	for _, qcfg := range controller.queues {
+		qcfg.QueueRef.Clear()
+	}
+	if controller.express != nil {
+		controller.express.Clear()
+	}
+
+	prs, err := controller.prlister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list placement requests: %w", err)
+	}
+
+	for _, pr := range prs {
+		if pr.DeletionTimestamp != nil || pr.Status.Result != v1alpha1.PlacementRequestResultUnknown {
+			continue
+		}
+		controller.enqueue(pr)
+	}
+
+	controller.logger.Info("queues rebuilt from cache", "placementRequests", len(prs))
+	return nil
+}
+
 // ScheduleOne is the function responsible for evaluating if a PlacementRequest
 // is valid and then bind it to the nodes. This function also sets the status
 // once it is finished.
-func (controller *PlacementRequestController) ScheduleOne(ctx context.Context, pr *v1alpha1.PlacementRequest) error {
-	prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+func (controller *PlacementRequestController) ScheduleOne(ctx context.Context, original *v1alpha1.PlacementRequest) error {
+	prid := map[string]string{"name": original.Name, "namespace": original.Namespace}
 	controller.logger.V(3).Info("processing placement request", "obj", prid)
 
 	// if the placement request is deleted or if its status is known
 	// (failure or success), we do not need to process it anymore.
-	if pr.DeletionTimestamp != nil || pr.Status.Result != v1alpha1.PlacementRequestResultUnknown {
+	if original.DeletionTimestamp != nil || original.Status.Result != v1alpha1.PlacementRequestResultUnknown {
 		controller.logger.V(3).Info("skipping placement request", "obj", prid)
 		return nil
 	}
 
-	// here we create a few shortcuts to api access entities we are going
-	// to use during this function. these shortcuts are already namespace
-	// scoped.
-	prqclient := controller.client.KombinerV1alpha1().PlacementRequests(pr.Namespace)
-	podlister := controller.podlister.Pods(pr.Namespace)
+	// we work on a copy so the original object can be requeued in case
+	// we fail to record the outcome.
+	pr := original.DeepCopy()
+
+	start := time.Now()
+	defer func() {
+		metrics.ScheduleOneDuration.WithLabelValues(
+			pr.Spec.SchedulerName, string(pr.Status.Result),
+		).Observe(time.Since(start).Seconds())
+	}()
 
 	if err := helpers.Validate(pr); err != nil {
 		controller.logger.Error(err, "placement request is not valid", "obj", prid)
 		pr.Status.Result = v1alpha1.PlacementRequestResultRejected
+		pr.Status.Reason = "InvalidPlacementRequest"
 		pr.Status.Message = err.Error()
-		_, err := prqclient.UpdateStatus(ctx, pr, metav1.UpdateOptions{})
+		metrics.Rejections.WithLabelValues(pr.Spec.SchedulerName, pr.Status.Reason).Inc()
+		helpers.SetCompleted(pr, metav1.Now())
+		controller.recorder.Event(pr, v1.EventTypeWarning, EventReasonRejected, pr.Status.Message)
+		return controller.updateStatus(ctx, pr)
+	}
+
+	// we let the world know we are about to bind. nothing has been bound
+	// yet so if this fails we can safely try again later.
+	helpers.SetBinding(pr, metav1.Now())
+	if err := controller.updateStatus(ctx, pr); err != nil {
+		controller.requeueOnTransientError(ctx, original, err)
 		return err
 	}
 
+	retries := &atomic.Int32{}
+	switch pr.Spec.Policy {
+	case v1alpha1.PlacementRequestPolicyAllOrNothing:
+		controller.bindAllOrNothing(ctx, pr, retries)
+	default:
+		controller.bindLenient(ctx, pr, retries)
+	}
+	pr.Status.Retries += retries.Load()
+
+	for _, binding := range pr.Status.Bindings {
+		metrics.BindingResults.WithLabelValues(
+			pr.Spec.SchedulerName, string(binding.Result), binding.Reason,
+		).Inc()
+	}
+
+	pr.Status.Result, pr.Status.Message = helpers.AssessResult(pr)
+	helpers.SetCompleted(pr, metav1.Now())
+	if err := controller.updateStatus(ctx, pr); err != nil {
+		// pods we have already bound will be seen as such in the next
+		// attempt.
+		controller.requeueOnTransientError(ctx, original, err)
+		return err
+	}
+
+	controller.recordCompletion(pr)
+	controller.logger.V(3).Info("placement request processed", "obj", prid)
+	return nil
+}
+
+// requeueOnTransientError puts the placement request back at the front of its
+// queue if we ran out of retries for a transient error. The number of times
+// this happened is kept in the placement request status, once it goes over
+// the retry budget the placement request fails. The status of the original
+// object is not changed.
+func (controller *PlacementRequestController) requeueOnTransientError(
+	ctx context.Context, original *v1alpha1.PlacementRequest, err error,
+) {
+	if !isTransient(err) || ctx.Err() != nil {
+		return
+	}
+
+	pr := original.DeepCopy()
+	pr.Status.Requeues++
+	prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+	if int(pr.Status.Requeues) <= controller.maxRetries {
+		controller.logger.Info("requeueing placement request", "obj", prid, "requeues", pr.Status.Requeues)
+		controller.requeue(pr)
+		return
+	}
+
+	pr.Status.Result = v1alpha1.PlacementRequestResultFailure
+	pr.Status.Reason = ReasonRetriesExhausted
+	pr.Status.Message = fmt.Sprintf("Gave up after %d requeues: %v", pr.Status.Requeues-1, err)
+	helpers.SetCompleted(pr, metav1.Now())
+	if err := controller.updateStatus(ctx, pr); err != nil {
+		controller.logger.Error(err, "failed to record placement request failure", "obj", prid)
+		return
+	}
+	controller.recordCompletion(pr)
+}
+
+// requeue pushes the placement request to the front of the queue it was
+// read from.
+func (controller *PlacementRequestController) requeue(pr *v1alpha1.PlacementRequest) {
+	if controller.isExpress(pr) {
+		controller.express.PushFront(pr)
+		return
+	}
+
+	if qcfg, found := controller.queues[pr.Spec.SchedulerName]; found {
+		qcfg.QueueRef.PushFront(pr)
+	}
+}
+
+// updateStatus updates the placement request status. Transient errors are
+// retried with backoff. On conflict we fetch the latest version of the object
+// and apply our status on top of it, unless it has been recreated in the
+// meantime. Every retry is accounted for in the status Retries field. On
+// success the provided placement request gets the new resource version so it
+// can be updated again.
+func (controller *PlacementRequestController) updateStatus(ctx context.Context, pr *v1alpha1.PlacementRequest) error {
+	prqclient := controller.client.KombinerV1alpha1().PlacementRequests(pr.Namespace)
+	retriable := func(err error) bool {
+		return isTransient(err) || apierrors.IsConflict(err)
+	}
+
+	status, current := pr.Status, pr.DeepCopy()
+	var updated *v1alpha1.PlacementRequest
+	_, err := controller.withRetries(
+		ctx, retriable,
+		func(attempt int) error {
+			var err error
+			current.Status = status
+			current.Status.Retries += int32(attempt)
+			updated, err = prqclient.UpdateStatus(ctx, current, metav1.UpdateOptions{})
+			if !apierrors.IsConflict(err) {
+				return err
+			}
+
+			latest, gerr := prqclient.Get(ctx, pr.Name, metav1.GetOptions{})
+			if gerr != nil {
+				return gerr
+			}
+
+			// placement requests are recreated under the same name, we
+			// must not write our status on top of a different one.
+			if latest.UID != pr.UID {
+				return fmt.Errorf("placement request %s/%s has been recreated", pr.Namespace, pr.Name)
+			}
+			current = latest
+			return err
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update placement request status: %w", err)
+	}
+
+	pr.ResourceVersion = updated.ResourceVersion
+	pr.Status.Retries = updated.Status.Retries
+	return nil
+}
+
+// bindLenient binds the pods of a Lenient placement request. Each binding is
+// verified and bound independently, a failure in one of them does not affect
+// the others. Up to bindingConcurrency bindings are processed concurrently.
+func (controller *PlacementRequestController) bindLenient(
+	ctx context.Context, pr *v1alpha1.PlacementRequest, retries *atomic.Int32,
+) {
+	invalid := controller.validate(ctx, pr)
+	capacity := controller.newNodeCapacity(pr)
+	results := make([]*v1alpha1.PlacementRequestBindingResult, len(pr.Spec.Bindings))
+	workqueue.ParallelizeUntil(
+		ctx, controller.bindingConcurrency, len(pr.Spec.Bindings),
+		func(i int) {
+			binding := pr.Spec.Bindings[i]
+			results[i] = controller.bindOne(ctx, pr, binding, invalid[binding.PodName], capacity, retries)
+		},
+	)
+
+	// results are set in the same order as the bindings in the spec. if
+	// the context was cancelled some of the bindings may not have been
+	// attempted at all.
+	for i, binding := range pr.Spec.Bindings {
+		if results[i] == nil {
+			message := "The placement request processing was interrupted"
+			helpers.SetPodBindingFailure(pr, binding, "Binding not attempted", message)
+			continue
+		}
+		helpers.SetPodBindingResult(pr, binding, results[i].Result, results[i].Reason, results[i].Message)
+	}
+}
+
+// bindOne verifies and binds a single pod of a Lenient placement request. The
+// result is returned instead of set in the placement request status as this
+// function is called concurrently. If the validate plugins have found the
+// binding invalid the error is provided and the pod is not bound. The pod
+// is only bound if its target node still has room for it.
+func (controller *PlacementRequestController) bindOne(
+	ctx context.Context,
+	pr *v1alpha1.PlacementRequest,
+	binding v1alpha1.Binding,
+	invalid error,
+	capacity *nodeCapacity,
+	retries *atomic.Int32,
+) *v1alpha1.PlacementRequestBindingResult {
+	prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+	controller.logger.V(3).Info("binding pod to node", "bind", binding, "obj", prid)
+
+	result := &v1alpha1.PlacementRequestBindingResult{
+		Binding: binding,
+		Result:  v1alpha1.PlacementRequestResultFailure,
+	}
+
+	if bound, err := controller.verifyBinding(pr, binding); err != nil {
+		controller.logger.Error(err, "binding verification failed", "bind", binding, "obj", prid)
+		result.Reason, result.Message = bindingErrorReason(err)
+		return result
+	} else if bound {
+		result.Result = v1alpha1.PlacementRequestResultSuccess
+		result.Reason, result.Message = "Binding unneeded", "Pod was already bound"
+		return result
+	}
+
+	if invalid != nil {
+		controller.logger.V(3).Info("binding rejected by validate plugins", "bind", binding, "obj", prid, "err", invalid)
+		result.Reason, result.Message = bindingErrorReason(invalid)
+		return result
+	}
+
+	pod, err := controller.reserve(capacity, pr.Namespace, binding)
+	if err != nil {
+		controller.logger.V(3).Info("binding does not fit", "bind", binding, "obj", prid, "err", err)
+		result.Reason, result.Message = bindingErrorReason(err)
+		return result
+	}
+
+	if err := controller.bind(ctx, pr.Namespace, binding, retries); err != nil {
+		controller.logger.Error(err, "failed to bind pod to node", "bind", binding, "obj", prid)
+		capacity.release(pod, binding.NodeName)
+		result.Reason, result.Message = "API denied binding", err.Error()
+		return result
+	}
+	controller.assumed.assume(pod, binding.NodeName)
+
+	controller.logger.V(3).Info("pod successfully bound to node", "bind", binding, "obj", prid)
+	result.Result = v1alpha1.PlacementRequestResultSuccess
+	result.Reason, result.Message = "Binding successful", "Pod successfully bound"
+	return result
+}
+
+// bindAllOrNothing binds the pods of an AllOrNothing placement request. All
+// bindings are verified before any pod is bound and if any of them fails the
+// verification nothing is bound at all. If a bind fails after some pods have
+// already been bound we stop and roll back the pods bound so far.
+func (controller *PlacementRequestController) bindAllOrNothing(
+	ctx context.Context, pr *v1alpha1.PlacementRequest, retries *atomic.Int32,
+) {
+	prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+
+	// first pass, we verify every single binding. pods that are already
+	// bound to their target node do not need to be bound again.
+	invalid := controller.validate(ctx, pr)
+	capacity := controller.newNodeCapacity(pr)
+	pods := map[string]*v1.Pod{}
+	var pending []v1alpha1.Binding
+	var failed bool
 	for _, binding := range pr.Spec.Bindings {
-		controller.logger.V(3).Info("binding pod to node", "bind", binding, "obj", prid)
+		if bound, err := controller.verifyBinding(pr, binding); err != nil {
+			controller.logger.Error(err, "binding verification failed", "bind", binding, "obj", prid)
+			setPodBindingError(pr, binding, err)
+			failed = true
+			continue
+		} else if bound {
+			helpers.SetPodBindingSuccess(pr, binding, "Binding unneeded", "Pod was already bound")
+			continue
+		}
 
-		if pod, err := podlister.Get(binding.PodName); err != nil {
-			controller.logger.Error(err, "failed to get pod")
-			message := fmt.Sprintf("Failed to get pod %s: %v", binding.PodName, err)
-			helpers.SetPodBindingFailure(pr, binding, "API error", message)
+		if err := invalid[binding.PodName]; err != nil {
+			controller.logger.V(3).Info("binding rejected by validate plugins", "bind", binding, "obj", prid, "err", err)
+			setPodBindingError(pr, binding, err)
+			failed = true
 			continue
-		} else if pod.Spec.NodeName != "" {
-			if pod.Spec.NodeName == binding.NodeName {
-				helpers.SetPodBindingSuccess(pr, binding, "Binding unneeded", "Pod was already bound")
-				continue
-			}
-			message := fmt.Sprintf("Pod %s bound to a different node node", binding.PodName)
-			helpers.SetPodBindingFailure(pr, binding, "Pod already bound", message)
+		}
+
+		pod, err := controller.reserve(capacity, pr.Namespace, binding)
+		if err != nil {
+			controller.logger.V(3).Info("binding does not fit", "bind", binding, "obj", prid, "err", err)
+			setPodBindingError(pr, binding, err)
+			failed = true
 			continue
 		}
+		pods[binding.PodName] = pod
+		pending = append(pending, binding)
+	}
+
+	if failed {
+		for _, binding := range pending {
+			message := "Another binding in the placement request failed verification"
+			helpers.SetPodBindingFailure(pr, binding, "Binding not attempted", message)
+		}
+		return
+	}
+
+	// second pass, all bindings seem to be valid so we can bind them. if
+	// the api server refuses any of them we roll back what we have done.
+	for i, binding := range pending {
+		controller.logger.V(3).Info("binding pod to node", "bind", binding, "obj", prid)
+
+		if err := controller.bind(ctx, pr.Namespace, binding, retries); err != nil {
+			controller.logger.Error(err, "failed to bind pod to node", "bind", binding, "obj", prid)
+			helpers.SetPodBindingFailure(pr, binding, "API denied binding", err.Error())
+			for _, skipped := range pending[i+1:] {
+				message := "Another binding in the placement request failed"
+				helpers.SetPodBindingFailure(pr, skipped, "Binding not attempted", message)
+			}
+			controller.rollback(ctx, pr, pending[:i], retries)
+			return
+		}
 
-		bind := &v1.Binding{
+		controller.logger.V(3).Info("pod successfully bound to node", "bind", binding, "obj", prid)
+		controller.assumed.assume(pods[binding.PodName], binding.NodeName)
+		helpers.SetPodBindingSuccess(pr, binding, "Binding successful", "Pod successfully bound")
+	}
+}
+
+// rollback is called when an AllOrNothing placement request fails after some
+// of its pods have already been bound. A pod can't be unbound so we evict the
+// ones we have bound instead, this gives their owners the chance to recreate
+// them. Pods we fail to evict are flagged in their binding result.
+func (controller *PlacementRequestController) rollback(
+	ctx context.Context, pr *v1alpha1.PlacementRequest, bindings []v1alpha1.Binding, retries *atomic.Int32,
+) {
+	prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+	evictor := controller.coreclient.Pods(pr.Namespace)
+	for _, binding := range bindings {
+		controller.logger.V(3).Info("evicting pod", "bind", binding, "obj", prid)
+
+		eviction := &policyv1.Eviction{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: pr.Namespace,
 				Name:      binding.PodName,
-				UID:       binding.PodUID,
 			},
-			Target: v1.ObjectReference{
-				Kind: "Node",
-				Name: binding.NodeName,
+			DeleteOptions: &metav1.DeleteOptions{
+				Preconditions: &metav1.Preconditions{UID: &binding.PodUID},
 			},
 		}
 
-		binder := controller.coreclient.Pods(pr.Namespace)
-		if err := binder.Bind(ctx, bind, metav1.CreateOptions{}); err != nil {
-			controller.logger.Error(err, "failed to bind pod to node", "bind", binding, "obj", prid)
-			helpers.SetPodBindingFailure(pr, binding, "API denied binding", err.Error())
+		attempts, err := controller.withRetries(
+			ctx, isTransient,
+			func(int) error {
+				return evictor.EvictV1(ctx, eviction)
+			},
+		)
+		retries.Add(int32(attempts))
+		if err != nil {
+			controller.logger.Error(err, "failed to evict pod", "bind", binding, "obj", prid)
+			message := fmt.Sprintf("Pod was bound but could not be evicted: %v", err)
+			helpers.SetPodBindingFailure(pr, binding, "Rollback failed", message)
 			continue
 		}
 
-		controller.logger.V(3).Info("pod successfully bound to node", "bind", binding, "obj", prid)
-		helpers.SetPodBindingSuccess(pr, binding, "Binding successful", "Pod successfully bound")
+		controller.assumed.forget(binding.PodUID)
+		message := "Pod evicted as another binding in the placement request failed"
+		helpers.SetPodBindingFailure(pr, binding, "Rolled back", message)
 	}
+}
 
-	pr.Status.Result, pr.Status.Message = helpers.AssessResult(pr)
-	if _, err := prqclient.UpdateStatus(ctx, pr, metav1.UpdateOptions{}); err != nil {
-		return fmt.Errorf("failed to update placement request status: %w", err)
+// verifyBinding checks if a binding can be fulfilled. It makes sure the pod
+// exists, has the expected UID, belongs to the scheduler that created the
+// placement request and it is not bound to a different node. It
+// also makes sure the target node exists, it is not being deleted, it is
+// ready and, unless allowed by the configuration, not cordoned. The
+// returned boolean indicates if the pod is already bound to the target node,
+// in such case there is no need to bind it again.
+func (controller *PlacementRequestController) verifyBinding(pr *v1alpha1.PlacementRequest, binding v1alpha1.Binding) (bool, error) {
+	pod, err := controller.podlister.Pods(pr.Namespace).Get(binding.PodName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			message := fmt.Sprintf("Pod %s does not exist", binding.PodName)
+			return false, &BindingError{Reason: "Pod not found", Message: message}
+		}
+		message := fmt.Sprintf("Failed to get pod %s: %v", binding.PodName, err)
+		return false, &BindingError{Reason: "API error", Message: message}
 	}
 
-	controller.logger.V(3).Info("placement request processed", "obj", prid)
-	return nil
+	if pod.UID != binding.PodUID {
+		message := fmt.Sprintf("Pod %s has UID %s", binding.PodName, pod.UID)
+		return false, &BindingError{Reason: "Pod UID mismatch", Message: message}
+	}
+
+	// pods without a scheduler name are handled by the default scheduler,
+	// a placement request can only bind pods of its own scheduler.
+	schedulerName := pod.Spec.SchedulerName
+	if schedulerName == "" {
+		schedulerName = v1.DefaultSchedulerName
+	}
+	if schedulerName != pr.Spec.SchedulerName {
+		message := fmt.Sprintf("Pod %s uses scheduler %s", binding.PodName, schedulerName)
+		return false, &BindingError{Reason: "Scheduler mismatch", Message: message}
+	}
+
+	if pod.Spec.NodeName != "" {
+		if pod.Spec.NodeName == binding.NodeName {
+			return true, nil
+		}
+		message := fmt.Sprintf("Pod %s bound to a different node", binding.PodName)
+		return false, &BindingError{Reason: "Pod already bound", Message: message}
+	}
+
+	node, err := controller.nodelister.Get(binding.NodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			message := fmt.Sprintf("Node %s does not exist", binding.NodeName)
+			return false, &BindingError{Reason: "Node not found", Message: message}
+		}
+		message := fmt.Sprintf("Failed to get node %s: %v", binding.NodeName, err)
+		return false, &BindingError{Reason: "API error", Message: message}
+	}
+
+	if node.DeletionTimestamp != nil {
+		message := fmt.Sprintf("Node %s is being deleted", binding.NodeName)
+		return false, &BindingError{Reason: "Node being deleted", Message: message}
+	}
+
+	if !nodeReady(node) && !tolerates(pod, v1.TaintNodeNotReady) {
+		message := fmt.Sprintf("Node %s is not ready", binding.NodeName)
+		return false, &BindingError{Reason: "Node not ready", Message: message}
+	}
+
+	cordoned := node.Spec.Unschedulable && !controller.allowCordonedNodes
+	if cordoned && !tolerates(pod, v1.TaintNodeUnschedulable) {
+		message := fmt.Sprintf("Node %s is cordoned", binding.NodeName)
+		return false, &BindingError{Reason: "Node cordoned", Message: message}
+	}
+
+	return false, nil
+}
+
+// nodeReady returns true if the node reports the Ready condition as true.
+// Nodes that haven't reported the condition yet are not considered ready.
+func nodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// tolerates returns true if the pod tolerates a NoSchedule taint with the
+// provided key. This is the same toleration the scheduler looks at for
+// nodes that are not ready or cordoned, DaemonSet pods carry some of them.
+func tolerates(pod *v1.Pod, key string) bool {
+	taint := &v1.Taint{Key: key, Effect: v1.TaintEffectNoSchedule}
+	for _, toleration := range pod.Spec.Tolerations {
+		if toleration.ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+// bind binds a single pod to its target node through the API server. Binds
+// failing with transient errors are retried, the number of retries is added
+// to the provided counter. A bind is not idempotent: if a previous attempt
+// reached the API server before timing out the retry is refused with a
+// Conflict, in such case we look at the pod and if it is bound to the target
+// node we consider the bind successful.
+func (controller *PlacementRequestController) bind(
+	ctx context.Context, namespace string, binding v1alpha1.Binding, retries *atomic.Int32,
+) error {
+	bind := &v1.Binding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      binding.PodName,
+			UID:       binding.PodUID,
+		},
+		Target: v1.ObjectReference{
+			Kind: "Node",
+			Name: binding.NodeName,
+		},
+	}
+	attempts, err := controller.withRetries(
+		ctx, isTransient,
+		func(attempt int) error {
+			err := controller.coreclient.Pods(namespace).Bind(ctx, bind, metav1.CreateOptions{})
+			if attempt == 0 || !apierrors.IsConflict(err) {
+				return err
+			}
+
+			pod, gerr := controller.coreclient.Pods(namespace).Get(ctx, binding.PodName, metav1.GetOptions{})
+			if gerr == nil && pod.UID == binding.PodUID && pod.Spec.NodeName == binding.NodeName {
+				return nil
+			}
+			return err
+		},
+	)
+	retries.Add(int32(attempts))
+	return err
+}
+
+// setPodBindingError records the provided error as the binding result.
+func setPodBindingError(pr *v1alpha1.PlacementRequest, binding v1alpha1.Binding, err error) {
+	reason, message := bindingErrorReason(err)
+	helpers.SetPodBindingFailure(pr, binding, reason, message)
+}
+
+// bindingErrorReason returns the reason and message for the provided error.
+// If the error is a BindingError its reason is used, otherwise a generic one
+// is returned.
+func bindingErrorReason(err error) (string, string) {
+	var berr *BindingError
+	if errors.As(err, &berr) {
+		return berr.Reason, berr.Message
+	}
+	return "Unknown error", err.Error()
 }
 
 // AddEventHandlers is used to make sure the informers are pointing to the
 // right event handlers here. We want to enqueue every new PlacementRequest
-// into our internal queues.
+// into our internal queues and keep them in sync when PlacementRequests are
+// updated or deleted. The handlers never run while the queues are being
+// rebuilt.
 func (controller *PlacementRequestController) AddEventHandlers(informer informer.PlacementRequestInformer) error {
 	if _, err := informer.Informer().AddEventHandler(
 		cache.FilteringResourceEventHandler{
 			FilterFunc: func(obj interface{}) bool {
-				switch obj.(type) {
+				switch t := obj.(type) {
 				case *v1alpha1.PlacementRequest:
 					return true
+				case cache.DeletedFinalStateUnknown:
+					_, ok := t.Obj.(*v1alpha1.PlacementRequest)
+					return ok
 				default:
 					return false
 				}
 			},
 			Handler: cache.ResourceEventHandlerFuncs{
-				AddFunc: controller.enqueue,
+				AddFunc: func(obj interface{}) {
+					controller.handlers.Lock()
+					defer controller.handlers.Unlock()
+					controller.enqueue(obj)
+				},
+				UpdateFunc: func(oldobj, newobj interface{}) {
+					controller.handlers.Lock()
+					defer controller.handlers.Unlock()
+					controller.update(oldobj, newobj)
+				},
+				DeleteFunc: func(obj interface{}) {
+					controller.handlers.Lock()
+					defer controller.handlers.Unlock()
+					controller.dequeue(obj)
+				},
 			},
 		},
 	); err != nil {
@@ -170,31 +798,145 @@ func (controller *PlacementRequestController) AddEventHandlers(informer informer
 // enqueue is called when a PlacementRequest is created on the cluster. This
 // function responsibility is to enqueue the respective PlacementRequest object
 // into one of our internal queues. We have one internal queue per scheduler
-// name. If a queue for the scheduler name does not exist, we create it
-// automatically. We should not take much long here as we haven't not yet
-// enqueued the placement request and there may be more events happening. We
-// do some basic validation here and in case of failure we just try to
-// reject the PlacementRequest.
+// name. We should not take much long here as we haven't not yet enqueued the
+// placement request and there may be more events happening so nothing is
+// written to the api server from here. Marking the PlacementRequest as
+// queued, or rejecting it if it can't be queued, is left for the status
+// worker.
 func (controller *PlacementRequestController) enqueue(obj interface{}) {
 	pr, ok := obj.(*v1alpha1.PlacementRequest)
 	if !ok || pr.Spec.SchedulerName == "" {
 		return
 	}
+	defer controller.syncStatusLater(pr)
+
+	if _, _, ok := controller.admit(pr); !ok {
+		return
+	}
+
+	if controller.isExpress(pr) {
+		prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+		controller.logger.V(5).Info("placement request goes express", "obj", prid)
+		controller.express.Push(pr)
+		return
+	}
 
+	controller.queues[pr.Spec.SchedulerName].QueueRef.Push(pr)
+}
+
+// admit checks if the PlacementRequest can be queued. If not the reason and
+// the message it should be rejected with are returned.
+func (controller *PlacementRequestController) admit(pr *v1alpha1.PlacementRequest) (string, string, bool) {
 	qcfg, found := controller.queues[pr.Spec.SchedulerName]
 	if !found {
-		reason, msg := "QueueNotFound", "Scheduler queue not found"
-		controller.TryToRejectPlacementRequest(pr, reason, msg)
-		return
+		return "QueueNotFound", "Scheduler queue not found", false
 	}
 
 	if len(pr.Spec.Bindings) > int(qcfg.MaxSize) {
-		reason, msg := "PlacementRequestTooLarge", "Placement request too large"
-		controller.TryToRejectPlacementRequest(pr, reason, msg)
+		return "PlacementRequestTooLarge", "Placement request too large", false
+	}
+	return "", "", true
+}
+
+// markQueued sets the placement request status as Queued and emits the
+// Queued event. Placement requests already marked as queued for their
+// current generation are left alone, this happens when the queues are
+// rebuilt. The provided object is not changed.
+func (controller *PlacementRequestController) markQueued(ctx context.Context, pr *v1alpha1.PlacementRequest) error {
+	if pr.Status.Phase == v1alpha1.PlacementRequestPhaseQueued && pr.Status.ObservedGeneration == pr.Generation {
+		return nil
+	}
+
+	pr = pr.DeepCopy()
+	helpers.SetQueued(pr, metav1.Now())
+	prqclient := controller.client.KombinerV1alpha1().PlacementRequests(pr.Namespace)
+	if _, err := prqclient.UpdateStatus(ctx, pr, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to mark placement request as queued: %w", err)
+	}
+
+	controller.recorder.Eventf(pr, v1.EventTypeNormal, EventReasonQueued, "Queued for scheduler %s", pr.Spec.SchedulerName)
+	return nil
+}
+
+// update is called when a PlacementRequest is updated on the cluster. If the
+// PlacementRequest has been finished or is being deleted we remove it from
+// its queue. If its spec has changed while it was still queued we replace
+// the queued entry so we don't process a stale object. PlacementRequests
+// that are not queued anymore (in flight or done) are left alone.
+func (controller *PlacementRequestController) update(oldobj, newobj interface{}) {
+	old, ok := oldobj.(*v1alpha1.PlacementRequest)
+	if !ok {
 		return
 	}
 
-	qcfg.QueueRef.Push(pr)
+	pr, ok := newobj.(*v1alpha1.PlacementRequest)
+	if !ok || old.ResourceVersion == pr.ResourceVersion {
+		return
+	}
+
+	if pr.DeletionTimestamp != nil || pr.Status.Result != v1alpha1.PlacementRequestResultUnknown {
+		controller.remove(old)
+		return
+	}
+
+	if equality.Semantic.DeepEqual(old.Spec, pr.Spec) {
+		// the status worker has marked it as queued, we keep the queued
+		// copy up to date so we don't process a stale object.
+		if pr.Status.Phase == v1alpha1.PlacementRequestPhaseQueued {
+			controller.replace(pr)
+		}
+		return
+	}
+
+	// the scheduler name may have changed so we remove the request from
+	// the old queue and enqueue it again. this also makes sure the new
+	// spec goes through the same validation as a new request would.
+	if controller.remove(old) {
+		controller.enqueue(pr)
+	}
+}
+
+// dequeue is called when a PlacementRequest is deleted from the cluster. We
+// remove it from its internal queue, if it is still there, so we do not use
+// fairness budget processing a request that does not exist anymore.
+func (controller *PlacementRequestController) dequeue(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	if pr, ok := obj.(*v1alpha1.PlacementRequest); ok {
+		controller.remove(pr)
+	}
+}
+
+// remove removes the PlacementRequest from its internal queue, or from the
+// express queue. Returns true if the PlacementRequest was queued.
+func (controller *PlacementRequestController) remove(pr *v1alpha1.PlacementRequest) bool {
+	qcfg, found := controller.queues[pr.Spec.SchedulerName]
+	if !found {
+		return false
+	}
+
+	prid := map[string]string{"name": pr.Name, "namespace": pr.Namespace}
+	key := queue.PlacementRequestKey(pr)
+	if !qcfg.QueueRef.Remove(key) && (controller.express == nil || !controller.express.Remove(key)) {
+		return false
+	}
+
+	controller.logger.V(5).Info("placement request removed from queue", "obj", prid)
+	return true
+}
+
+// replace replaces the queued copy of the PlacementRequest, either in its
+// queue or in the express queue. Nothing happens if it isn't queued.
+func (controller *PlacementRequestController) replace(pr *v1alpha1.PlacementRequest) {
+	qcfg, found := controller.queues[pr.Spec.SchedulerName]
+	if !found || qcfg.QueueRef.Replace(pr) {
+		return
+	}
+	if controller.express != nil {
+		controller.express.Replace(pr)
+	}
 }
 
 // TryToRejectPlacementRequest should be used when rejecting a PlacementRequest
@@ -211,24 +953,42 @@ func (controller *PlacementRequestController) TryToRejectPlacementRequest(
 	)
 	defer cancel()
 
+	if err := controller.reject(ctx, pr, reason, message); err != nil {
+		controller.logger.Error(err, "failed to reject placement request", "obj", prid)
+	}
+}
+
+// reject sets the PlacementRequest result as Rejected with the provided reason
+// and message. The provided object is not changed.
+func (controller *PlacementRequestController) reject(
+	ctx context.Context, pr *v1alpha1.PlacementRequest, reason, message string,
+) error {
+	pr = pr.DeepCopy()
 	pr.Status.Result = v1alpha1.PlacementRequestResultRejected
 	pr.Status.Reason = reason
 	pr.Status.Message = message
+	helpers.SetCompleted(pr, metav1.Now())
 
 	prqclient := controller.client.KombinerV1alpha1().PlacementRequests(pr.Namespace)
 	if _, err := prqclient.UpdateStatus(ctx, pr, metav1.UpdateOptions{}); err != nil {
-		controller.logger.Error(err, "failed to reject placement request", "obj", prid)
+		return fmt.Errorf("failed to reject placement request: %w", err)
 	}
+
+	controller.recorder.Event(pr, v1.EventTypeWarning, EventReasonRejected, message)
+	metrics.Rejections.WithLabelValues(pr.Spec.SchedulerName, reason).Inc()
+	return nil
 }
 
-// New returns a PlacementRequest controller.
+// New returns a PlacementRequest controller. The pod informer gets indexed
+// by node name so it must be started only after the controller is created.
 func New(
 	ctx context.Context,
 	cfg configapi.Configuration,
 	client client.Interface,
 	coreclient corev1client.CoreV1Interface,
 	informer informer.PlacementRequestInformer,
-	podlister corev1listers.PodLister,
+	podinformer coreinformers.PodInformer,
+	nodelister corev1listers.NodeLister,
 	opts ...Option,
 ) (*PlacementRequestController, error) {
 	options := defaultOptions
@@ -236,21 +996,67 @@ func New(
 		opt(&options)
 	}
 
+	if err := podinformer.Informer().AddIndexers(
+		cache.Indexers{podNodeNameIndex: indexPodByNodeName},
+	); err != nil {
+		return nil, fmt.Errorf("failed to index pods by node name: %w", err)
+	}
+	podlister := podinformer.Lister()
+
 	configs := queue.QueueConfigFromV1Alpha1Config(cfg)
 	if err := configs.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid queue configuration: %w", err)
 	}
 
-	itopts := []queue.QueueIteratorOption{}
-	switch cfg.FairnessAlgorithm {
-	case "", configapi.RoundRobin:
-		options.logger.Info("using the default round-robin fairness algorithm")
-		itopts = append(itopts, queue.WithReaderFactory(queue.NewRoundRobinReader))
-	case configapi.Uniform:
-		options.logger.Info("using the uniform fairness algorithm")
-		itopts = append(itopts, queue.WithReaderFactory(queue.NewUniformReader))
-	default:
-		return nil, fmt.Errorf("unknown fairness algorithm %q", cfg.FairnessAlgorithm)
+	algorithm := cfg.FairnessAlgorithm
+	if algorithm == "" {
+		algorithm = configapi.RoundRobin
+	}
+
+	builder, ok := queue.Lookup(algorithm)
+	if !ok {
+		return nil, fmt.Errorf("unknown fairness algorithm %q", algorithm)
+	}
+
+	handle := queue.Handle{
+		Resources: &listerResourceLookup{
+			logger:     options.logger,
+			podlister:  podlister,
+			nodelister: nodelister,
+		},
+	}
+
+	options.logger.Info("using fairness algorithm", "algorithm", algorithm)
+	factory, err := builder(cfg.FairnessAlgorithmArgs, handle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build fairness algorithm %q: %w", algorithm, err)
+	}
+
+	// with tiers the fairness algorithm only applies among queues in the
+	// same tier.
+	if len(cfg.Tiers) > 0 {
+		options.logger.Info("using queue tiers", "tiers", cfg.Tiers)
+		factory = queue.NewTieredReaderFactory(cfg.Tiers, factory)
+	}
+	itopts := []queue.QueueIteratorOption{queue.WithReaderFactory(factory)}
+
+	var fairness *queue.FairnessState
+	if cfg.FairnessState != nil {
+		options.logger.Info("keeping fairness state", "window", cfg.FairnessState.Window.Duration)
+		fairness = queue.NewFairnessState(cfg.FairnessState.Window.Duration)
+		itopts = append(itopts, queue.WithFairnessState(fairness))
+	}
+
+	var express *queue.PlacementRequestQueue
+	if cfg.ExpressLane != nil {
+		options.logger.Info("using an express queue", "qps", cfg.ExpressLane.QPS, "burst", cfg.ExpressLane.Burst)
+		express = queue.NewPlacementRequestQueue(queue.WithRateLimit(newExpressLimiter(cfg.ExpressLane)))
+		itopts = append(itopts, queue.WithExpressQueue(express))
+	}
+
+	validators, err := newValidators(ctx, cfg, configs, options.informerFactory)
+	if err != nil {
+		return nil, err
 	}
 
 	iterator, err := queue.NewQueueIterator(configs, itopts...)
@@ -263,9 +1069,31 @@ func New(
 		client:     client,
 		coreclient: coreclient,
 		podlister:  podlister,
+		podindexer: podinformer.Informer().GetIndexer(),
+		nodelister: nodelister,
 		prlister:   informer.Lister(),
 		queues:     configs.ToMap(),
 		iterator:   iterator,
+		algorithm:  algorithm,
+		inflight:   newInflight(),
+		assumed:    newAssumedPods(),
+		ttl:        cfg.TTLSecondsAfterFinished,
+		fairness:   fairness,
+		express:    express,
+		validators: validators,
+		statuses:   newStatusQueue(),
+
+		allowCordonedNodes: cfg.AllowCordonedNodes,
+	}
+
+	if cfg.FairnessState != nil {
+		controller.fairnessConfigMap = cfg.FairnessState.ConfigMap
+	}
+	controller.expressLane = cfg.ExpressLane
+
+	for _, qcfg := range configs {
+		metrics.RegisterQueue(qcfg.SchedulerName, qcfg.QueueRef.Len)
+		qcfg.QueueRef.AddThrottleHandler(metrics.QueueThrottled.WithLabelValues(qcfg.SchedulerName).Inc)
 	}
 
 	if err := controller.AddEventHandlers(informer); err != nil {
@@ -0,0 +1,681 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/generated/clientset/versioned/fake"
+	lister "kombiner/pkg/generated/listers/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+)
+
+// newTestController returns a controller backed by fake clients and listers
+// populated with the provided pods and nodes.
+func newTestController(
+	t *testing.T, pr *v1alpha1.PlacementRequest, pods []*corev1.Pod, nodes []*corev1.Node,
+) (*PlacementRequestController, *kubefake.Clientset) {
+	podidx := cache.NewIndexer(
+		cache.MetaNamespaceKeyFunc, cache.Indexers{podNodeNameIndex: indexPodByNodeName},
+	)
+	nodeidx := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+
+	objs := []runtime.Object{}
+	for _, pod := range pods {
+		require.NoError(t, podidx.Add(pod))
+		objs = append(objs, pod)
+	}
+	for _, node := range nodes {
+		require.NoError(t, nodeidx.Add(node))
+		objs = append(objs, node)
+	}
+
+	kubecli := kubefake.NewClientset(objs...)
+	controller := &PlacementRequestController{
+		options:    defaultOptions,
+		client:     fake.NewSimpleClientset(pr),
+		coreclient: kubecli.CoreV1(),
+		podlister:  corev1listers.NewPodLister(podidx),
+		podindexer: podidx,
+		nodelister: corev1listers.NewNodeLister(nodeidx),
+		assumed:    newAssumedPods(),
+	}
+	return controller, kubecli
+}
+
+func testPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns",
+			UID:       types.UID("uid-" + name),
+		},
+		Spec: corev1.PodSpec{SchedulerName: "scheduler"},
+	}
+}
+
+func testNode(name string) *corev1.Node {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("8Gi"),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Allocatable: allocatable,
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func testPlacementRequest(policy v1alpha1.PlacementRequestPolicy, pods ...string) *v1alpha1.PlacementRequest {
+	pr := &v1alpha1.PlacementRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns"},
+		Spec: v1alpha1.PlacementRequestSpec{
+			Policy:        policy,
+			SchedulerName: "scheduler",
+		},
+	}
+	for _, pod := range pods {
+		pr.Spec.Bindings = append(
+			pr.Spec.Bindings,
+			v1alpha1.Binding{PodName: pod, PodUID: types.UID("uid-" + pod), NodeName: "node"},
+		)
+	}
+	return pr
+}
+
+// actions returns the pod subresource actions (bindings and evictions) the
+// controller has sent to the api server.
+func actions(kubecli *kubefake.Clientset, subresource string) []string {
+	var names []string
+	for _, action := range kubecli.Actions() {
+		create, ok := action.(clienttesting.CreateAction)
+		if !ok || action.GetSubresource() != subresource {
+			continue
+		}
+		obj, _ := create.GetObject().(metav1.Object)
+		names = append(names, obj.GetName())
+	}
+	return names
+}
+
+// stored returns the placement request as stored in the api server.
+func stored(t *testing.T, controller *PlacementRequestController, pr *v1alpha1.PlacementRequest) *v1alpha1.PlacementRequest {
+	prqclient := controller.client.KombinerV1alpha1().PlacementRequests(pr.Namespace)
+	current, err := prqclient.Get(context.Background(), pr.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return current
+}
+
+func results(pr *v1alpha1.PlacementRequest) map[string]string {
+	res := map[string]string{}
+	for _, binding := range pr.Status.Bindings {
+		res[binding.Binding.PodName] = binding.Reason
+	}
+	return res
+}
+
+func TestScheduleOneAllOrNothingVerification(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyAllOrNothing, "a", "b", "c")
+	pods := []*corev1.Pod{testPod("a"), testPod("c")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Empty(actions(kubecli, "binding"), "no pod should have been bound")
+	require.Equal(v1alpha1.PlacementRequestResultFailure, pr.Status.Result)
+	require.Equal(
+		map[string]string{
+			"a": "Binding not attempted",
+			"b": "Pod not found",
+			"c": "Binding not attempted",
+		},
+		results(pr),
+	)
+}
+
+func TestScheduleOneAllOrNothingRollback(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyAllOrNothing, "a", "b", "c")
+	pods := []*corev1.Pod{testPod("a"), testPod("b"), testPod("c")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+	kubecli.PrependReactor(
+		"create", "pods",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			create := action.(clienttesting.CreateAction)
+			if action.GetSubresource() != "binding" {
+				return false, nil, nil
+			}
+			if create.GetObject().(*corev1.Binding).Name == "b" {
+				return true, nil, errors.New("injected error")
+			}
+			return false, nil, nil
+		},
+	)
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Equal([]string{"a", "b"}, actions(kubecli, "binding"))
+	require.Equal([]string{"a"}, actions(kubecli, "eviction"))
+	require.Equal(v1alpha1.PlacementRequestResultFailure, pr.Status.Result)
+	require.Equal(
+		map[string]string{
+			"a": "Rolled back",
+			"b": "API denied binding",
+			"c": "Binding not attempted",
+		},
+		results(pr),
+	)
+}
+
+func TestScheduleOneAllOrNothingSuccess(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyAllOrNothing, "a", "b")
+	pods := []*corev1.Pod{testPod("a"), testPod("b")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Equal([]string{"a", "b"}, actions(kubecli, "binding"))
+	require.Equal(v1alpha1.PlacementRequestResultSuccess, pr.Status.Result)
+	require.Equal(v1alpha1.PlacementRequestPhaseCompleted, pr.Status.Phase)
+	require.NotNil(pr.Status.StartedAt)
+	require.NotNil(pr.Status.CompletedAt)
+	require.True(meta.IsStatusConditionTrue(pr.Status.Conditions, v1alpha1.PlacementRequestConditionCompleted))
+}
+
+func TestScheduleOneLenient(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b")
+	pods := []*corev1.Pod{testPod("a"), testPod("b")}
+	pods[1].UID = "other"
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Equal([]string{"a"}, actions(kubecli, "binding"))
+	require.Equal(v1alpha1.PlacementRequestResultPartialSuccess, pr.Status.Result)
+	require.Equal(
+		map[string]string{
+			"a": "Binding successful",
+			"b": "Pod UID mismatch",
+		},
+		results(pr),
+	)
+}
+
+func TestScheduleOneSchedulerMismatch(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b")
+	pods := []*corev1.Pod{testPod("a"), testPod("b")}
+	pods[1].Spec.SchedulerName = ""
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Equal([]string{"a"}, actions(kubecli, "binding"))
+	require.Equal(
+		map[string]string{
+			"a": "Binding successful",
+			"b": "Scheduler mismatch",
+		},
+		results(pr),
+	)
+}
+
+func TestScheduleOneNodeHealth(t *testing.T) {
+	cordoned := testNode("cordoned")
+	cordoned.Spec.Unschedulable = true
+	notready := testNode("notready")
+	notready.Status.Conditions[0].Status = corev1.ConditionFalse
+	unknown := testNode("unknown")
+	unknown.Status.Conditions = nil
+	nodes := []*corev1.Node{cordoned, notready, unknown}
+
+	tolerating := testPod("tolerating")
+	tolerating.Spec.Tolerations = []corev1.Toleration{
+		{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	}
+
+	for name, tt := range map[string]struct {
+		node        string
+		pod         *corev1.Pod
+		allowCordon bool
+		result      string
+	}{
+		"cordoned": {
+			node:   "cordoned",
+			pod:    testPod("pod"),
+			result: "Node cordoned",
+		},
+		"cordoned allowed": {
+			node:        "cordoned",
+			pod:         testPod("pod"),
+			allowCordon: true,
+			result:      "Binding successful",
+		},
+		"cordoned tolerated": {
+			node:   "cordoned",
+			pod:    tolerating,
+			result: "Binding successful",
+		},
+		"not ready": {
+			node:   "notready",
+			pod:    testPod("pod"),
+			result: "Node not ready",
+		},
+		"no ready condition": {
+			node:   "unknown",
+			pod:    testPod("pod"),
+			result: "Node not ready",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, tt.pod.Name)
+			pr.Spec.Bindings[0].NodeName = tt.node
+			controller, _ := newTestController(t, pr, []*corev1.Pod{tt.pod}, nodes)
+			controller.allowCordonedNodes = tt.allowCordon
+
+			require.NoError(t, controller.ScheduleOne(context.Background(), pr))
+			pr = stored(t, controller, pr)
+			require.Equal(t, map[string]string{tt.pod.Name: tt.result}, results(pr))
+		})
+	}
+}
+
+func TestEventHandlersKeepQueuesInSync(t *testing.T) {
+	require := require.New(t)
+
+	configs := queue.QueueConfigFromV1Alpha1Config(
+		configapi.Configuration{
+			Queues: []configapi.Queue{
+				{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+				{SchedulerName: "other", Weight: 1, MaxSize: 10},
+			},
+		},
+	)
+	first := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	first.Name, first.ResourceVersion = "first", "1"
+	second := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "b")
+	second.Name, second.ResourceVersion = "second", "1"
+
+	controller := &PlacementRequestController{
+		options: defaultOptions,
+		queues:  configs.ToMap(),
+		client:  fake.NewSimpleClientset(first, second),
+	}
+	scheduler := controller.queues["scheduler"].QueueRef
+	other := controller.queues["other"].QueueRef
+
+	controller.enqueue(first)
+	controller.enqueue(second)
+	require.Equal(2, scheduler.Len())
+
+	// a resync must not change anything.
+	controller.update(first, first.DeepCopy())
+	require.Equal(2, scheduler.Len())
+
+	// moving to a different scheduler moves it to a different queue.
+	moved := first.DeepCopy()
+	moved.ResourceVersion = "2"
+	moved.Spec.SchedulerName = "other"
+	controller.update(first, moved)
+	require.Equal(1, scheduler.Len())
+	require.Equal(1, other.Len())
+
+	// a spec update replaces the queued entry.
+	updated := moved.DeepCopy()
+	updated.ResourceVersion = "3"
+	updated.Spec.Priority = 10
+	controller.update(moved, updated)
+	require.Equal(1, other.Len())
+	require.Equal(v1alpha1.PlacementRequestPriority(10), other.Pop().Spec.Priority)
+
+	// deleting a queued placement request removes it from the queue,
+	// also when we only get to see its tombstone.
+	controller.dequeue(cache.DeletedFinalStateUnknown{Key: "ns/second", Obj: second})
+	require.Equal(0, scheduler.Len())
+}
+
+func TestRebuildQueues(t *testing.T) {
+	require := require.New(t)
+
+	pending := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pending.Name = "pending"
+	done := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "b")
+	done.Name = "done"
+	done.Status.Result = v1alpha1.PlacementRequestResultSuccess
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(indexer.Add(pending))
+	require.NoError(indexer.Add(done))
+
+	configs := queue.QueueConfigFromV1Alpha1Config(
+		configapi.Configuration{
+			Queues: []configapi.Queue{
+				{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+			},
+		},
+	)
+	controller := &PlacementRequestController{
+		options:  defaultOptions,
+		queues:   configs.ToMap(),
+		client:   fake.NewSimpleClientset(pending, done),
+		prlister: lister.NewPlacementRequestLister(indexer),
+	}
+
+	// a request processed by a previous leader is still in our queue.
+	queueref := controller.queues["scheduler"].QueueRef
+	queueref.Push(done)
+
+	require.NoError(controller.RebuildQueues())
+	require.Equal(1, queueref.Len())
+	require.Equal("pending", queueref.Pop().Name)
+}
+
+// racingLister adds a placement request to the queue while the placement
+// requests are being listed, as an event handler would.
+type racingLister struct {
+	lister.PlacementRequestLister
+	race func()
+}
+
+func (l *racingLister) List(selector labels.Selector) ([]*v1alpha1.PlacementRequest, error) {
+	l.race()
+	return l.PlacementRequestLister.List(selector)
+}
+
+func TestRebuildQueuesKeepsConcurrentAdds(t *testing.T) {
+	require := require.New(t)
+
+	late := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	late.Name = "late"
+
+	configs := queue.QueueConfigFromV1Alpha1Config(
+		configapi.Configuration{
+			Queues: []configapi.Queue{
+				{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+			},
+		},
+	)
+	queueref := configs.ToMap()["scheduler"].QueueRef
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	controller := &PlacementRequestController{
+		options: defaultOptions,
+		queues:  configs.ToMap(),
+		client:  fake.NewSimpleClientset(late),
+		prlister: &racingLister{
+			PlacementRequestLister: lister.NewPlacementRequestLister(indexer),
+			race:                   func() { queueref.Push(late) },
+		},
+	}
+
+	require.NoError(controller.RebuildQueues())
+	require.Equal(1, queueref.Len())
+	require.Equal("late", queueref.Pop().Name)
+}
+
+func TestScheduleOneRetriesTransientErrors(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b")
+	pods := []*corev1.Pod{testPod("a"), testPod("b")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+	controller.retryBackoff.Duration = time.Millisecond
+
+	// the first bind of "a" is throttled, the bind of "b" is always denied.
+	throttled := false
+	kubecli.PrependReactor(
+		"create", "pods",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			create := action.(clienttesting.CreateAction)
+			if action.GetSubresource() != "binding" {
+				return false, nil, nil
+			}
+			switch create.GetObject().(*corev1.Binding).Name {
+			case "a":
+				if !throttled {
+					throttled = true
+					return true, nil, apierrors.NewTooManyRequests("slow down", 1)
+				}
+			case "b":
+				return true, nil, apierrors.NewForbidden(corev1.Resource("pods"), "b", errors.New("denied"))
+			}
+			return false, nil, nil
+		},
+	)
+
+	// the first status update hits an internal error.
+	prcli := controller.client.(*fake.Clientset)
+	failed := false
+	prcli.PrependReactor(
+		"update", "placementrequests",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "status" || failed {
+				return false, nil, nil
+			}
+			failed = true
+			return true, nil, apierrors.NewInternalError(errors.New("etcd unavailable"))
+		},
+	)
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	require.Equal([]string{"a", "a", "b"}, actions(kubecli, "binding"))
+
+	pr = stored(t, controller, pr)
+	require.Equal(v1alpha1.PlacementRequestResultPartialSuccess, pr.Status.Result)
+	require.Equal(int32(2), pr.Status.Retries)
+	require.Equal(
+		map[string]string{
+			"a": "Binding successful",
+			"b": "API denied binding",
+		},
+		results(pr),
+	)
+}
+
+func TestScheduleOneRequeuesOnExhaustedRetries(t *testing.T) {
+	require := require.New(t)
+
+	configs := queue.QueueConfigFromV1Alpha1Config(
+		configapi.Configuration{
+			Queues: []configapi.Queue{
+				{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+			},
+		},
+	)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	controller, _ := newTestController(t, pr, []*corev1.Pod{testPod("a")}, []*corev1.Node{testNode("node")})
+	controller.queues = configs.ToMap()
+	controller.retryBackoff.Duration = time.Millisecond
+	controller.maxRetries = 2
+
+	prcli := controller.client.(*fake.Clientset)
+	prcli.PrependReactor(
+		"update", "placementrequests",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewServiceUnavailable("unavailable")
+		},
+	)
+
+	// a request with a higher priority must not take the place of the
+	// requeued one.
+	other := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	other.Name, other.Spec.Priority = "other", 100
+	controller.queues["scheduler"].QueueRef.Push(other)
+
+	require.Error(controller.ScheduleOne(context.Background(), pr))
+	require.Equal(2, controller.queues["scheduler"].QueueRef.Len())
+	require.Equal(v1alpha1.PlacementRequestResultUnknown, pr.Status.Result, "original object must not be changed")
+	require.Zero(pr.Status.Requeues, "original object must not be changed")
+
+	requeued := controller.queues["scheduler"].QueueRef.Pop()
+	require.Equal("pr", requeued.Name)
+	require.Equal(int32(1), requeued.Status.Requeues)
+}
+
+func TestScheduleOneFailsOnExhaustedRequeues(t *testing.T) {
+	require := require.New(t)
+
+	configs := queue.QueueConfigFromV1Alpha1Config(
+		configapi.Configuration{
+			Queues: []configapi.Queue{
+				{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+			},
+		},
+	)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pr.Status.Requeues = 2
+	controller, kubecli := newTestController(t, pr, []*corev1.Pod{testPod("a")}, []*corev1.Node{testNode("node")})
+	controller.queues = configs.ToMap()
+	controller.retryBackoff.Duration = time.Millisecond
+	controller.maxRetries = 2
+
+	// the status update marking the request as binding fails for all of
+	// its attempts, the api server then comes back.
+	failures := 0
+	prcli := controller.client.(*fake.Clientset)
+	prcli.PrependReactor(
+		"update", "placementrequests",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if failures > controller.maxRetries {
+				return false, nil, nil
+			}
+			failures++
+			return true, nil, apierrors.NewServiceUnavailable("unavailable")
+		},
+	)
+
+	require.Error(controller.ScheduleOne(context.Background(), pr))
+	require.Zero(controller.queues["scheduler"].QueueRef.Len())
+	require.Empty(actions(kubecli, "binding"))
+
+	pr = stored(t, controller, pr)
+	require.Equal(v1alpha1.PlacementRequestResultFailure, pr.Status.Result)
+	require.Equal(ReasonRetriesExhausted, pr.Status.Reason)
+	require.Equal(int32(3), pr.Status.Requeues)
+	require.NotNil(pr.Status.CompletedAt)
+}
+
+func TestBindConflictAfterTimeout(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyAllOrNothing, "a", "b")
+	pods := []*corev1.Pod{testPod("a"), testPod("b")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+	controller.retryBackoff.Duration = time.Millisecond
+
+	// the first bind of "a" reaches the api server but the client times
+	// out, the retry is then refused as the pod is already assigned.
+	timedout := false
+	kubecli.PrependReactor(
+		"create", "pods",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			create := action.(clienttesting.CreateAction)
+			if action.GetSubresource() != "binding" || create.GetObject().(*corev1.Binding).Name != "a" {
+				return false, nil, nil
+			}
+			if timedout {
+				return true, nil, apierrors.NewConflict(corev1.Resource("pods/binding"), "a", errors.New("pod a is already assigned to node \"node\""))
+			}
+
+			timedout = true
+			pod := testPod("a")
+			pod.Spec.NodeName = "node"
+			require.NoError(kubecli.Tracker().Update(corev1.SchemeGroupVersion.WithResource("pods"), pod, "ns"))
+			return true, nil, apierrors.NewTimeoutError("request timed out", 1)
+		},
+	)
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	require.Equal([]string{"a", "a", "b"}, actions(kubecli, "binding"))
+	require.Empty(actions(kubecli, "eviction"), "nothing should have been rolled back")
+
+	pr = stored(t, controller, pr)
+	require.Equal(v1alpha1.PlacementRequestResultSuccess, pr.Status.Result)
+	require.Equal(
+		map[string]string{
+			"a": "Binding successful",
+			"b": "Binding successful",
+		},
+		results(pr),
+	)
+}
+
+func TestUpdateStatusConflictWithRecreated(t *testing.T) {
+	require := require.New(t)
+
+	// the placement request has been deleted and recreated with the same
+	// name while we were processing it.
+	recreated := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	recreated.UID = "uid-recreated"
+	controller, _ := newTestController(t, recreated, nil, nil)
+	controller.retryBackoff.Duration = time.Millisecond
+
+	prcli := controller.client.(*fake.Clientset)
+	prcli.PrependReactor(
+		"update", "placementrequests",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			return true, nil, apierrors.NewConflict(v1alpha1.Resource("placementrequests"), "pr", errors.New("modified"))
+		},
+	)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pr.UID = "uid-original"
+	pr.Status.Result = v1alpha1.PlacementRequestResultSuccess
+	require.Error(controller.updateStatus(context.Background(), pr))
+
+	updates := 0
+	for _, action := range prcli.Actions() {
+		if action.GetVerb() == "update" {
+			updates++
+		}
+	}
+	require.Equal(1, updates, "no update should be attempted on the recreated object")
+	require.Equal(v1alpha1.PlacementRequestResultUnknown, stored(t, controller, pr).Status.Result)
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// ComponentName is the name the controller uses when emitting events.
+const ComponentName = "kombiner-controller"
+
+// Reasons used in the events emitted by the controller.
+const (
+	// EventReasonQueued is emitted on a PlacementRequest once it has been
+	// added to its scheduler queue.
+	EventReasonQueued = "Queued"
+
+	// EventReasonRejected is emitted on a PlacementRequest that won't be
+	// processed, for example because it is invalid.
+	EventReasonRejected = "Rejected"
+
+	// EventReasonCompleted is emitted on a PlacementRequest once all its
+	// bindings have been processed.
+	EventReasonCompleted = "Completed"
+
+	// EventReasonBoundByKombiner is emitted on a Pod that has been bound
+	// by the controller.
+	EventReasonBoundByKombiner = "BoundByKombiner"
+
+	// EventReasonBindingFailed is emitted on a Pod whose binding could not
+	// be fulfilled.
+	EventReasonBindingFailed = "BindingFailed"
+)
+
+// recordCompletion emits the events related to a processed PlacementRequest.
+// One event is emitted on the PlacementRequest itself and another one on
+// each of the pods it has bound or failed to bind. Pods that were already
+// bound to the right node are not affected so we don't emit anything for
+// them.
+func (controller *PlacementRequestController) recordCompletion(pr *v1alpha1.PlacementRequest) {
+	eventtype := v1.EventTypeNormal
+	if pr.Status.Result == v1alpha1.PlacementRequestResultFailure {
+		eventtype = v1.EventTypeWarning
+	}
+	controller.recorder.Eventf(
+		pr, eventtype, EventReasonCompleted, "Completed with result %s: %s", pr.Status.Result, pr.Status.Message,
+	)
+
+	for _, binding := range pr.Status.Bindings {
+		pod := &v1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  pr.Namespace,
+			Name:       binding.Binding.PodName,
+			UID:        binding.Binding.PodUID,
+		}
+
+		switch {
+		case binding.Result == v1alpha1.PlacementRequestResultSuccess && binding.Reason == "Binding unneeded":
+		case binding.Result == v1alpha1.PlacementRequestResultSuccess:
+			controller.recorder.Eventf(
+				pod, v1.EventTypeNormal, EventReasonBoundByKombiner,
+				"Bound to node %s by placement request %s", binding.Binding.NodeName, pr.Name,
+			)
+		default:
+			controller.recorder.Event(
+				pod, v1.EventTypeWarning, EventReasonBindingFailed, bindingEventMessage(pr, binding),
+			)
+		}
+	}
+}
+
+// bindingEventMessage returns the message for a BindingFailed event. It
+// carries the reason from the binding result.
+func bindingEventMessage(pr *v1alpha1.PlacementRequest, binding v1alpha1.PlacementRequestBindingResult) string {
+	msg := fmt.Sprintf(
+		"Binding to node %s by placement request %s failed: %s",
+		binding.Binding.NodeName, pr.Name, binding.Reason,
+	)
+	if binding.Message != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, binding.Message)
+	}
+	return msg
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// events drains the events recorded by the fake recorder.
+func events(recorder *record.FakeRecorder) []string {
+	var evts []string
+	for {
+		select {
+		case evt := <-recorder.Events:
+			evts = append(evts, evt)
+		default:
+			return evts
+		}
+	}
+}
+
+func TestScheduleOneEvents(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b", "c")
+	pods := []*corev1.Pod{testPod("a"), testPod("b"), testPod("c")}
+	pods[1].UID = "other"
+	pods[2].Spec.NodeName = "node"
+	controller, _ := newTestController(t, pr, pods, []*corev1.Node{testNode("node")})
+	recorder := record.NewFakeRecorder(10)
+	controller.recorder = recorder
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	require.Equal(
+		[]string{
+			"Normal Completed Completed with result PartialSuccess: 2 of 3 bindings succeeded",
+			"Normal BoundByKombiner Bound to node node by placement request pr",
+			"Warning BindingFailed Binding to node node by placement request pr failed: Pod UID mismatch (Pod b has UID other)",
+		},
+		events(recorder),
+	)
+}
+
+func TestScheduleOneRejectionEvent(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicy("Unknown"), "a")
+	controller, _ := newTestController(t, pr, nil, nil)
+	recorder := record.NewFakeRecorder(10)
+	controller.recorder = recorder
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	require.Equal(
+		[]string{"Warning Rejected unsupported policy: Unknown"},
+		events(recorder),
+	)
+}
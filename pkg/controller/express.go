@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+
+	"golang.org/x/time/rate"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// DefaultExpressQPS is the amount of bindings per second served out of the
+// express queue if not configured otherwise.
+const DefaultExpressQPS = 10
+
+// isExpress returns true if the PlacementRequest matches any of the express
+// lane rules. Rules based on pods only match if all the pods in the
+// PlacementRequest match, pods not found in the cache never match. The
+// priority rule looks at the priority resolved by the api server for each
+// pod, the PlacementRequest priority is set by its creator and can't be
+// trusted.
+func (controller *PlacementRequestController) isExpress(pr *v1alpha1.PlacementRequest) bool {
+	rules := controller.expressLane
+	if rules == nil || controller.express == nil {
+		return false
+	}
+
+	if slices.Contains(rules.Namespaces, pr.Namespace) {
+		return true
+	}
+
+	podRules := rules.MinPriority != nil || len(rules.PriorityClassNames) > 0 || rules.DaemonSets
+	if len(pr.Spec.Bindings) == 0 || !podRules {
+		return false
+	}
+
+	priority, critical, daemons := true, true, true
+	for _, binding := range pr.Spec.Bindings {
+		pod, err := controller.podlister.Pods(pr.Namespace).Get(binding.PodName)
+		if err != nil {
+			return false
+		}
+		priority = priority && hasMinPriority(pod, rules.MinPriority)
+		critical = critical && slices.Contains(rules.PriorityClassNames, pod.Spec.PriorityClassName)
+		daemons = daemons && rules.DaemonSets && isDaemonSetPod(pod)
+	}
+	return priority || critical || daemons
+}
+
+// hasMinPriority returns true if the pod priority is at least the provided
+// one. Pods without a priority never match.
+func hasMinPriority(pod *v1.Pod, threshold *int32) bool {
+	return threshold != nil && pod.Spec.Priority != nil && *pod.Spec.Priority >= *threshold
+}
+
+// isDaemonSetPod returns true if the pod is controlled by a DaemonSet.
+func isDaemonSetPod(pod *v1.Pod) bool {
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && owner.Kind == "DaemonSet"
+}
+
+// newExpressLimiter returns the rate limiter for the express queue, one token
+// per binding.
+func newExpressLimiter(cfg *configapi.ExpressLane) *rate.Limiter {
+	qps := cfg.QPS
+	if qps == 0 {
+		qps = DefaultExpressQPS
+	}
+
+	burst := cfg.Burst
+	if burst == 0 {
+		burst = qps
+	}
+	return rate.NewLimiter(rate.Limit(qps), int(burst))
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/metrics"
+	"kombiner/pkg/queue"
+)
+
+func TestIsExpress(t *testing.T) {
+	critical := testPod("critical")
+	critical.Spec.PriorityClassName = "system-node-critical"
+
+	important := testPod("important")
+	important.Spec.Priority = ptr.To[int32](1000)
+
+	daemon := testPod("daemon")
+	daemon.OwnerReferences = []metav1.OwnerReference{
+		{Kind: "DaemonSet", Name: "ds", Controller: ptr.To(true)},
+	}
+
+	regular := testPod("regular")
+	pods := []*corev1.Pod{critical, important, daemon, regular}
+
+	rules := &configapi.ExpressLane{
+		MinPriority:        ptr.To[int32](1000),
+		Namespaces:         []string{"kube-system"},
+		PriorityClassNames: []string{"system-node-critical"},
+		DaemonSets:         true,
+	}
+
+	for _, tt := range []struct {
+		name     string
+		pr       func() *v1alpha1.PlacementRequest
+		expected bool
+	}{
+		{
+			name: "regular pod",
+			pr: func() *v1alpha1.PlacementRequest {
+				return testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "regular")
+			},
+		},
+		{
+			name: "high priority pod",
+			pr: func() *v1alpha1.PlacementRequest {
+				return testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "important")
+			},
+			expected: true,
+		},
+		{
+			name: "high priority and regular pods",
+			pr: func() *v1alpha1.PlacementRequest {
+				return testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "important", "regular")
+			},
+		},
+		{
+			name: "high placement request priority",
+			pr: func() *v1alpha1.PlacementRequest {
+				pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "regular")
+				pr.Spec.Priority = 1 << 40
+				return pr
+			},
+		},
+		{
+			name: "express namespace",
+			pr: func() *v1alpha1.PlacementRequest {
+				pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "regular")
+				pr.Namespace = "kube-system"
+				return pr
+			},
+			expected: true,
+		},
+		{
+			name: "critical priority class",
+			pr: func() *v1alpha1.PlacementRequest {
+				return testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "critical")
+			},
+			expected: true,
+		},
+		{
+			name: "daemonset pod",
+			pr: func() *v1alpha1.PlacementRequest {
+				return testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "daemon")
+			},
+			expected: true,
+		},
+		{
+			name: "critical and regular pods",
+			pr: func() *v1alpha1.PlacementRequest {
+				return testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "critical", "regular")
+			},
+		},
+		{
+			name: "unknown pod",
+			pr: func() *v1alpha1.PlacementRequest {
+				return testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "unknown")
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := tt.pr()
+			controller, _ := newTestController(t, pr, pods, nil)
+			controller.express = queue.NewPlacementRequestQueue()
+			controller.expressLane = rules
+			require.Equal(t, tt.expected, controller.isExpress(pr))
+		})
+	}
+}
+
+func TestEnqueueExpress(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pod := testPod("a")
+	pod.Spec.Priority = ptr.To[int32](2000000000)
+
+	configs := queue.QueueConfigFromV1Alpha1Config(
+		configapi.Configuration{
+			Queues: []configapi.Queue{
+				{SchedulerName: "scheduler", Weight: 1, MaxSize: 10},
+			},
+		},
+	)
+
+	controller, _ := newTestController(t, pr, []*corev1.Pod{pod}, nil)
+	controller.queues = configs.ToMap()
+	controller.express = queue.NewPlacementRequestQueue()
+	controller.expressLane = &configapi.ExpressLane{MinPriority: ptr.To[int32](1000)}
+
+	controller.enqueue(pr)
+	require.Equal(1, controller.express.Len())
+	require.Zero(controller.queues["scheduler"].QueueRef.Len())
+
+	// the request is removed from the express queue once it is deleted.
+	controller.dequeue(pr)
+	require.Zero(controller.express.Len())
+}
+
+func TestObservePopExpress(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pod := testPod("a")
+	pod.Spec.Priority = ptr.To[int32](2000000000)
+
+	controller, _ := newTestController(t, pr, []*corev1.Pod{pod}, nil)
+	controller.algorithm = configapi.RoundRobin
+	controller.express = queue.NewPlacementRequestQueue()
+	controller.expressLane = &configapi.ExpressLane{MinPriority: ptr.To[int32](1000)}
+
+	express := metrics.BindingsServed.WithLabelValues("scheduler", metrics.ExpressAlgorithm)
+	fairness := metrics.BindingsServed.WithLabelValues("scheduler", string(configapi.RoundRobin))
+	expressBefore, fairnessBefore := testutil.ToFloat64(express), testutil.ToFloat64(fairness)
+
+	// express requests are not accounted as served by the fairness
+	// algorithm.
+	controller.observePop(pr)
+	require.Equal(expressBefore+1, testutil.ToFloat64(express))
+	require.Equal(fairnessBefore, testutil.ToFloat64(fairness))
+
+	controller.expressLane = &configapi.ExpressLane{MinPriority: ptr.To[int32](2000000001)}
+	controller.observePop(pr)
+	require.Equal(expressBefore+1, testutil.ToFloat64(express))
+	require.Equal(fairnessBefore+1, testutil.ToFloat64(fairness))
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"kombiner/pkg/queue"
+)
+
+// FairnessStateKey is the ConfigMap key under which the fairness state is
+// saved.
+const FairnessStateKey = "state.json"
+
+// LoadFairnessState restores the fairness state from its ConfigMap. A missing
+// ConfigMap is not an error, it means we are starting from scratch.
+func (controller *PlacementRequestController) LoadFairnessState(ctx context.Context) error {
+	ref := controller.fairnessConfigMap
+	cm, err := controller.coreclient.ConfigMaps(ref.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get fairness state: %w", err)
+	}
+
+	data, ok := cm.Data[FairnessStateKey]
+	if !ok {
+		return nil
+	}
+
+	var snapshot queue.FairnessSnapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return fmt.Errorf("failed to decode fairness state: %w", err)
+	}
+
+	controller.fairness.Restore(snapshot)
+	return nil
+}
+
+// SaveFairnessState saves the fairness state in its ConfigMap, creating it if
+// needed.
+func (controller *PlacementRequestController) SaveFairnessState(ctx context.Context) error {
+	data, err := json.Marshal(controller.fairness.Snapshot())
+	if err != nil {
+		return fmt.Errorf("failed to encode fairness state: %w", err)
+	}
+
+	ref := controller.fairnessConfigMap
+	cmclient := controller.coreclient.ConfigMaps(ref.Namespace)
+	cm, err := cmclient.Get(ctx, ref.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: ref.Namespace},
+			Data:       map[string]string{FairnessStateKey: string(data)},
+		}
+		if _, err := cmclient.Create(ctx, cm, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create fairness state: %w", err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get fairness state: %w", err)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[FairnessStateKey] = string(data)
+	if _, err := cmclient.Update(ctx, cm, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update fairness state: %w", err)
+	}
+	return nil
+}
+
+// persistFairnessState loads the fairness state and then saves it every
+// fairnessSaveInterval until the context is done. There is no save on the way
+// out: once the context is done we may not be the leader anymore and the new
+// leader may already be saving its own state. Whatever was recorded since the
+// last save is lost.
+func (controller *PlacementRequestController) persistFairnessState(ctx context.Context) {
+	if controller.fairness == nil || controller.fairnessConfigMap == nil {
+		return
+	}
+
+	if err := controller.LoadFairnessState(ctx); err != nil {
+		controller.logger.Error(err, "starting with an empty fairness state")
+	}
+
+	go wait.UntilWithContext(
+		ctx,
+		func(ctx context.Context) {
+			if err := controller.SaveFairnessState(ctx); err != nil {
+				controller.logger.Error(err, "failed to save fairness state")
+			}
+		},
+		controller.fairnessSaveInterval,
+	)
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/queue"
+)
+
+func TestFairnessStatePersistence(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	ref := &configapi.ConfigMapReference{Namespace: "kube-system", Name: "fairness"}
+	kubecli := kubefake.NewClientset()
+
+	controller := &PlacementRequestController{
+		options:           defaultOptions,
+		coreclient:        kubecli.CoreV1(),
+		fairness:          queue.NewFairnessState(time.Hour),
+		fairnessConfigMap: ref,
+	}
+
+	// nothing has been saved yet so we start from scratch.
+	require.NoError(controller.LoadFairnessState(ctx))
+	require.Empty(controller.fairness.Usage())
+
+	controller.fairness.Record("a", 3)
+	require.NoError(controller.SaveFairnessState(ctx))
+	controller.fairness.Record("b", 2)
+	require.NoError(controller.SaveFairnessState(ctx))
+
+	cm, err := kubecli.CoreV1().ConfigMaps("kube-system").Get(ctx, "fairness", metav1.GetOptions{})
+	require.NoError(err)
+	require.Contains(cm.Data, FairnessStateKey)
+
+	restarted := &PlacementRequestController{
+		options:           defaultOptions,
+		coreclient:        kubecli.CoreV1(),
+		fairness:          queue.NewFairnessState(time.Hour),
+		fairnessConfigMap: ref,
+	}
+	require.NoError(restarted.LoadFairnessState(ctx))
+	require.Equal(map[string]int{"a": 3, "b": 2}, restarted.fairness.Usage())
+}
+
+func TestLoadFairnessStateInvalid(t *testing.T) {
+	require := require.New(t)
+
+	kubecli := kubefake.NewClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "fairness"},
+		Data:       map[string]string{FairnessStateKey: "not json"},
+	})
+
+	controller := &PlacementRequestController{
+		options:           defaultOptions,
+		coreclient:        kubecli.CoreV1(),
+		fairness:          queue.NewFairnessState(time.Hour),
+		fairnessConfigMap: &configapi.ConfigMapReference{Namespace: "kube-system", Name: "fairness"},
+	}
+	require.Error(controller.LoadFairnessState(context.Background()))
+}
+
+func TestPersistFairnessStateStopsWithContext(t *testing.T) {
+	require := require.New(t)
+
+	kubecli := kubefake.NewClientset()
+	controller := &PlacementRequestController{
+		options:           defaultOptions,
+		coreclient:        kubecli.CoreV1(),
+		fairness:          queue.NewFairnessState(time.Hour),
+		fairnessConfigMap: &configapi.ConfigMapReference{Namespace: "kube-system", Name: "fairness"},
+	}
+	controller.fairnessSaveInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	controller.persistFairnessState(ctx)
+	require.Eventually(
+		func() bool {
+			_, err := kubecli.CoreV1().ConfigMaps("kube-system").Get(ctx, "fairness", metav1.GetOptions{})
+			return err == nil
+		},
+		time.Second, time.Millisecond,
+	)
+
+	// once we stop being the leader nothing else is written, the new
+	// leader may already be saving its own state.
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	kubecli.ClearActions()
+	controller.fairness.Record("a", 1)
+	time.Sleep(10 * time.Millisecond)
+	require.Empty(kubecli.Actions())
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// inflight keeps track of the pods and nodes touched by the PlacementRequests
+// currently being processed. It is used to make sure two PlacementRequests
+// touching the same pod or node are never processed at the same time.
+type inflight struct {
+	mtx     sync.Mutex
+	keys    map[string]bool
+	changed chan struct{}
+}
+
+// conflictKeys returns the keys a PlacementRequest needs to hold while it is
+// being processed. We have one key per pod and one key per node.
+func conflictKeys(pr *v1alpha1.PlacementRequest) []string {
+	keys := make([]string, 0, 2*len(pr.Spec.Bindings))
+	for _, binding := range pr.Spec.Bindings {
+		keys = append(
+			keys,
+			"pod/"+pr.Namespace+"/"+binding.PodName,
+			"node/"+binding.NodeName,
+		)
+	}
+	return keys
+}
+
+// acquire blocks until none of the provided keys are held by someone else and
+// then holds all of them. Returns false if the context is done before the
+// keys could be acquired.
+func (i *inflight) acquire(ctx context.Context, keys []string) bool {
+	for {
+		i.mtx.Lock()
+		if !i.conflicts(keys) {
+			for _, key := range keys {
+				i.keys[key] = true
+			}
+			i.mtx.Unlock()
+			return true
+		}
+		changed := i.changed
+		i.mtx.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// conflicts returns true if any of the provided keys is already held. This
+// function expects the mutex to be locked by the caller.
+func (i *inflight) conflicts(keys []string) bool {
+	for _, key := range keys {
+		if i.keys[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// release releases the provided keys and wakes up anyone waiting for them.
+func (i *inflight) release(keys []string) {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	for _, key := range keys {
+		delete(i.keys, key)
+	}
+	close(i.changed)
+	i.changed = make(chan struct{})
+}
+
+// newInflight returns an empty inflight tracker.
+func newInflight() *inflight {
+	return &inflight{
+		keys:    map[string]bool{},
+		changed: make(chan struct{}),
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+func TestInflightConflicts(t *testing.T) {
+	require := require.New(t)
+
+	first := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	second := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "b")
+	second.Spec.Bindings[0].NodeName = "other-node"
+	third := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "c")
+
+	tracker := newInflight()
+	ctx := context.Background()
+	require.True(tracker.acquire(ctx, conflictKeys(first)))
+
+	// a different pod to a different node does not conflict.
+	require.True(tracker.acquire(ctx, conflictKeys(second)))
+
+	// a different pod to the same node does conflict, we should block
+	// until the first request is released.
+	acquired := make(chan bool)
+	go func() {
+		acquired <- tracker.acquire(ctx, conflictKeys(third))
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired keys held by someone else")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	tracker.release(conflictKeys(first))
+	require.True(<-acquired)
+
+	// a cancelled context gives up waiting.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.False(tracker.acquire(cancelled, conflictKeys(first)))
+}
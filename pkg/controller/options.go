@@ -17,8 +17,12 @@ limitations under the License.
 package controller
 
 import (
+	"math"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 )
 
@@ -29,12 +33,37 @@ type Option func(*options)
 type options struct {
 	logger             klog.Logger
 	tryToRejectTimeout time.Duration
+	workers            int
+	bindingConcurrency int
+	maxRetries         int
+	retryBackoff       wait.Backoff
+	cleanupInterval    time.Duration
+	recorder           record.EventRecorder
+
+	fairnessSaveInterval time.Duration
+	queueTimeoutInterval time.Duration
+	informerFactory      informers.SharedInformerFactory
 }
 
 // defaultOptions holds the default options for a PlacementRequest controller.
 var defaultOptions = options{
 	logger:             klog.NewKlogr(),
 	tryToRejectTimeout: 2 * time.Second,
+	workers:            1,
+	bindingConcurrency: 1,
+	maxRetries:         5,
+	retryBackoff: wait.Backoff{
+		Duration: 100 * time.Millisecond,
+		Factor:   2,
+		Jitter:   0.1,
+		Steps:    math.MaxInt32,
+		Cap:      5 * time.Second,
+	},
+	cleanupInterval: 30 * time.Second,
+	// a fake recorder without a channel discards all events.
+	recorder:             &record.FakeRecorder{},
+	fairnessSaveInterval: 30 * time.Second,
+	queueTimeoutInterval: 5 * time.Second,
 }
 
 // WithLogger sets the logger for the PlacementRequest controller.
@@ -46,10 +75,98 @@ func WithLogger(logger klog.Logger) Option {
 
 // WithTryToRejectTimeout sets the timeout for trying to reject a PlacementRequest.
 // This value is used by the controller only when rejecting a placement request
-// is not that important and can fail without causing major consequences. XXX this
+// is not that important and can fail without causing major consequences. It is
+// also the timeout for each status update done by the status worker. XXX this
 // isn't exposed through the API but it might so let's keep this option here.
 func WithTryToRejectTimeout(timeout time.Duration) Option {
 	return func(o *options) {
 		o.tryToRejectTimeout = timeout
 	}
 }
+
+// WithWorkers sets how many PlacementRequests can be processed concurrently.
+// PlacementRequests touching the same pods or nodes are never processed at
+// the same time regardless of this value.
+func WithWorkers(workers int) Option {
+	return func(o *options) {
+		if workers > 0 {
+			o.workers = workers
+		}
+	}
+}
+
+// WithBindingConcurrency sets how many bindings of a single Lenient
+// PlacementRequest can be bound concurrently.
+func WithBindingConcurrency(concurrency int) Option {
+	return func(o *options) {
+		if concurrency > 0 {
+			o.bindingConcurrency = concurrency
+		}
+	}
+}
+
+// WithMaxRetries sets how many times a call to the API server is retried when
+// it fails with a transient error (throttling, timeouts, 5xx responses). Zero
+// disables retries.
+func WithMaxRetries(retries int) Option {
+	return func(o *options) {
+		if retries >= 0 {
+			o.maxRetries = retries
+		}
+	}
+}
+
+// WithRetryBackoff sets the backoff used in between retries of transient API
+// errors.
+func WithRetryBackoff(backoff wait.Backoff) Option {
+	return func(o *options) {
+		o.retryBackoff = backoff
+	}
+}
+
+// WithCleanupInterval sets how often the controller looks for finished
+// PlacementRequests whose time to live has expired.
+func WithCleanupInterval(interval time.Duration) Option {
+	return func(o *options) {
+		if interval > 0 {
+			o.cleanupInterval = interval
+		}
+	}
+}
+
+// WithEventRecorder sets the recorder used to emit events on PlacementRequests
+// and Pods. By default events are discarded.
+func WithEventRecorder(recorder record.EventRecorder) Option {
+	return func(o *options) {
+		o.recorder = recorder
+	}
+}
+
+// WithFairnessSaveInterval sets how often the fairness state is saved to its
+// ConfigMap, if one is configured.
+func WithFairnessSaveInterval(interval time.Duration) Option {
+	return func(o *options) {
+		if interval > 0 {
+			o.fairnessSaveInterval = interval
+		}
+	}
+}
+
+// WithInformerFactory sets the informer factory used by the validate plugins
+// to reach for the objects they need. It is required if any validate plugin
+// is enabled and it must be started only after the controller is created.
+func WithInformerFactory(factory informers.SharedInformerFactory) Option {
+	return func(o *options) {
+		o.informerFactory = factory
+	}
+}
+
+// WithQueueTimeoutInterval sets how often the controller looks for queued
+// PlacementRequests that have been waiting for longer than allowed.
+func WithQueueTimeoutInterval(interval time.Duration) Option {
+	return func(o *options) {
+		if interval > 0 {
+			o.queueTimeoutInterval = interval
+		}
+	}
+}
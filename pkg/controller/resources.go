@@ -0,0 +1,85 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	resourcehelper "k8s.io/component-helpers/resource"
+	"k8s.io/klog/v2"
+	v1helper "k8s.io/kubernetes/pkg/apis/core/v1/helper"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+)
+
+// This global variable is used to ensure that listerResourceLookup implements
+// the queue.ResourceLookup interface.
+var _ queue.ResourceLookup = &listerResourceLookup{}
+
+// listerResourceLookup finds out pod requests and the cluster capacity out of
+// the informer caches. Only CPU, memory and extended resources are taken into
+// account.
+type listerResourceLookup struct {
+	logger     klog.Logger
+	podlister  corev1listers.PodLister
+	nodelister corev1listers.NodeLister
+}
+
+// Capacity returns the sum of the allocatable resources of all nodes.
+func (l *listerResourceLookup) Capacity() corev1.ResourceList {
+	nodes, err := l.nodelister.List(labels.Everything())
+	if err != nil {
+		l.logger.Error(err, "failed to list nodes")
+		return corev1.ResourceList{}
+	}
+
+	capacity := corev1.ResourceList{}
+	for _, node := range nodes {
+		addResources(capacity, node.Status.Allocatable)
+	}
+	return capacity
+}
+
+// Requests returns the sum of the resources requested by the pods in the
+// PlacementRequest. Pods that can't be found are not accounted for, they
+// won't be bound anyway.
+func (l *listerResourceLookup) Requests(pr *v1alpha1.PlacementRequest) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, binding := range pr.Spec.Bindings {
+		pod, err := l.podlister.Pods(pr.Namespace).Get(binding.PodName)
+		if err != nil {
+			continue
+		}
+		addResources(requests, resourcehelper.PodRequests(pod, resourcehelper.PodResourcesOptions{}))
+	}
+	return requests
+}
+
+// addResources adds the CPU, memory and extended resources from src to dst.
+func addResources(dst, src corev1.ResourceList) {
+	for name, quantity := range src {
+		if name != corev1.ResourceCPU && name != corev1.ResourceMemory && !v1helper.IsExtendedResourceName(name) {
+			continue
+		}
+
+		current := dst[name]
+		current.Add(quantity)
+		dst[name] = current
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+func TestListerResourceLookup(t *testing.T) {
+	require := require.New(t)
+
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("2"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+		corev1.ResourcePods:   resource.MustParse("10"),
+		"nvidia.com/gpu":      resource.MustParse("1"),
+	}
+
+	pods := []*corev1.Pod{testPod("a"), testPod("b")}
+	for _, pod := range pods {
+		pod.Spec.Containers = []corev1.Container{
+			{Name: "main", Resources: corev1.ResourceRequirements{Requests: resources}},
+		}
+	}
+
+	nodes := []*corev1.Node{testNode("node-1"), testNode("node-2")}
+	for _, node := range nodes {
+		node.Status.Allocatable = resources
+	}
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b", "missing")
+	controller, _ := newTestController(t, pr, pods, nodes)
+	lookup := &listerResourceLookup{
+		logger:     controller.logger,
+		podlister:  controller.podlister,
+		nodelister: controller.nodelister,
+	}
+
+	expected := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+		"nvidia.com/gpu":      resource.MustParse("2"),
+	}
+
+	requests := lookup.Requests(pr)
+	capacity := lookup.Capacity()
+	for name, quantity := range expected {
+		require.Truef(quantity.Equal(requests[name]), "unexpected %s requests: %v", name, requests)
+		require.Truef(quantity.Equal(capacity[name]), "unexpected %s capacity: %v", name, capacity)
+	}
+	require.Len(requests, len(expected), "only cpu, memory and extended resources are accounted")
+	require.Len(capacity, len(expected), "only cpu, memory and extended resources are accounted")
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+)
+
+// ReasonRetriesExhausted is used as the result reason of a PlacementRequest
+// that was requeued more times than the retry budget allows.
+const ReasonRetriesExhausted = "RetriesExhausted"
+
+// isTransient returns true if the provided error is likely to go away if we
+// try again. This includes throttling, server side timeouts, 5xx responses
+// and connectivity issues. Errors such as Conflict, NotFound or Forbidden are
+// considered permanent as trying again won't change the outcome.
+func isTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch {
+	case apierrors.IsTooManyRequests(err),
+		apierrors.IsServerTimeout(err),
+		apierrors.IsTimeout(err),
+		apierrors.IsInternalError(err),
+		apierrors.IsServiceUnavailable(err),
+		apierrors.IsUnexpectedServerError(err):
+		return true
+	}
+
+	if utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+
+	var neterr net.Error
+	return errors.As(err, &neterr) && neterr.Timeout()
+}
+
+// withRetries calls fn until it succeeds, returns an error for which retriable
+// returns false or the retry budget is exhausted. Between attempts we wait
+// following an exponential backoff. The attempt number, starting at zero, is
+// passed to fn. Returns the number of retries and the last error.
+func (controller *PlacementRequestController) withRetries(
+	ctx context.Context, retriable func(error) bool, fn func(attempt int) error,
+) (int, error) {
+	backoff := controller.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn(attempt)
+		if err == nil || !retriable(err) || attempt >= controller.maxRetries {
+			return attempt, err
+		}
+
+		controller.logger.V(3).Info("retrying after transient error", "attempt", attempt+1, "error", err.Error())
+
+		timer := time.NewTimer(backoff.Step())
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		}
+	}
+}
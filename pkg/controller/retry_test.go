@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func TestIsTransient(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"nil", nil, false},
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), true},
+		{"server timeout", apierrors.NewServerTimeout(corev1.Resource("pods"), "bind", 1), true},
+		{"timeout", apierrors.NewTimeoutError("timeout", 1), true},
+		{"internal error", apierrors.NewInternalError(errors.New("boom")), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("unavailable"), true},
+		{"connection refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), true},
+		{"not found", apierrors.NewNotFound(corev1.Resource("pods"), "pod"), false},
+		{"conflict", apierrors.NewConflict(corev1.Resource("pods"), "pod", errors.New("conflict")), false},
+		{"forbidden", apierrors.NewForbidden(corev1.Resource("pods"), "pod", errors.New("no")), false},
+		{"generic", errors.New("generic"), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, isTransient(tt.err))
+		})
+	}
+}
+
+func TestWithRetries(t *testing.T) {
+	require := require.New(t)
+
+	controller := &PlacementRequestController{options: defaultOptions}
+	controller.retryBackoff.Duration = time.Millisecond
+	controller.maxRetries = 3
+
+	transient := apierrors.NewTooManyRequests("slow down", 1)
+
+	// succeeds after two failures.
+	calls := 0
+	retries, err := controller.withRetries(
+		context.Background(), isTransient,
+		func(attempt int) error {
+			require.Equal(calls, attempt)
+			if calls++; calls < 3 {
+				return transient
+			}
+			return nil
+		},
+	)
+	require.NoError(err)
+	require.Equal(2, retries)
+
+	// gives up once max retries is reached.
+	calls = 0
+	retries, err = controller.withRetries(
+		context.Background(), isTransient,
+		func(int) error {
+			calls++
+			return transient
+		},
+	)
+	require.ErrorIs(err, transient)
+	require.Equal(3, retries)
+	require.Equal(4, calls)
+
+	// permanent errors are not retried.
+	calls = 0
+	retries, err = controller.withRetries(
+		context.Background(), isTransient,
+		func(int) error {
+			calls++
+			return errors.New("permanent")
+		},
+	)
+	require.Error(err)
+	require.Equal(0, retries)
+	require.Equal(1, calls)
+}
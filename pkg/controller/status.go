@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+)
+
+// newStatusQueue returns the work queue feeding the status worker.
+func newStatusQueue() workqueue.TypedRateLimitingInterface[string] {
+	return workqueue.NewTypedRateLimitingQueueWithConfig(
+		workqueue.DefaultTypedControllerRateLimiter[string](),
+		workqueue.TypedRateLimitingQueueConfig[string]{Name: "placementrequest-status"},
+	)
+}
+
+// syncStatusLater asks the status worker to look at the PlacementRequest. The
+// event handlers run on every replica, standby replicas must not write to the
+// api server so we only do this while we are the leader. The queues are
+// rebuilt when we become the leader so nothing is lost.
+func (controller *PlacementRequestController) syncStatusLater(pr *v1alpha1.PlacementRequest) {
+	if controller.leading.Load() {
+		controller.statuses.Add(queue.PlacementRequestKey(pr))
+	}
+}
+
+// runStatusWorker processes the keys added through syncStatusLater until the
+// provided context is done. Failed keys are retried with backoff up to the
+// retry budget.
+func (controller *PlacementRequestController) runStatusWorker(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		controller.statuses.ShutDown()
+	}()
+
+	for controller.processNextStatus(ctx) {
+	}
+}
+
+// processNextStatus syncs the status of the next PlacementRequest in the
+// status queue. Returns false once the queue has been shut down.
+func (controller *PlacementRequestController) processNextStatus(ctx context.Context) bool {
+	key, quit := controller.statuses.Get()
+	if quit {
+		return false
+	}
+	defer controller.statuses.Done(key)
+
+	err := controller.syncStatus(ctx, key)
+	if err == nil || ctx.Err() != nil {
+		controller.statuses.Forget(key)
+		return true
+	}
+
+	if controller.statuses.NumRequeues(key) < controller.maxRetries {
+		controller.logger.V(3).Info("retrying placement request status update", "key", key, "error", err.Error())
+		controller.statuses.AddRateLimited(key)
+		return true
+	}
+
+	controller.logger.Error(err, "giving up on placement request status update", "key", key)
+	controller.statuses.Forget(key)
+	return true
+}
+
+// syncStatus rejects the PlacementRequest if it could not be queued or marks
+// it as queued otherwise. We always look at the latest version in the cache,
+// PlacementRequests that are gone, finished or that have already left their
+// queue are left alone.
+func (controller *PlacementRequestController) syncStatus(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil
+	}
+
+	pr, err := controller.prlister.PlacementRequests(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if pr.DeletionTimestamp != nil || pr.Status.Result != v1alpha1.PlacementRequestResultUnknown {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, controller.tryToRejectTimeout)
+	defer cancel()
+
+	if reason, message, ok := controller.admit(pr); !ok {
+		return controller.reject(ctx, pr, reason, message)
+	}
+
+	if !controller.queued(key) {
+		return nil
+	}
+	return controller.markQueued(ctx, pr)
+}
+
+// queued returns true if the PlacementRequest with the provided key is waiting
+// in one of the queues, including the express one.
+func (controller *PlacementRequestController) queued(key string) bool {
+	for _, qcfg := range controller.queues {
+		if qcfg.QueueRef.Has(key) {
+			return true
+		}
+	}
+	return controller.express != nil && controller.express.Has(key)
+}
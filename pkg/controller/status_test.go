@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/tools/cache"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/generated/clientset/versioned/fake"
+	lister "kombiner/pkg/generated/listers/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+)
+
+// newStatusTestController returns a controller with a single queue of up to
+// one binding whose lister and api server hold the provided requests.
+func newStatusTestController(t *testing.T, prs ...*v1alpha1.PlacementRequest) *PlacementRequestController {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	clientset := fake.NewSimpleClientset()
+	for _, pr := range prs {
+		require.NoError(t, indexer.Add(pr))
+		require.NoError(t, clientset.Tracker().Add(pr))
+	}
+
+	configs := queue.QueueConfigFromV1Alpha1Config(
+		configapi.Configuration{
+			Queues: []configapi.Queue{
+				{SchedulerName: "scheduler", Weight: 1, MaxSize: 1},
+			},
+		},
+	)
+
+	return &PlacementRequestController{
+		options:  defaultOptions,
+		queues:   configs.ToMap(),
+		client:   clientset,
+		prlister: lister.NewPlacementRequestLister(indexer),
+		statuses: newStatusQueue(),
+	}
+}
+
+func TestEnqueueOnStandby(t *testing.T) {
+	require := require.New(t)
+
+	large := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b")
+	large.Name = "large"
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	controller := newStatusTestController(t, pr, large)
+
+	// standby replicas keep their queues up to date but never write.
+	controller.enqueue(pr)
+	controller.enqueue(large)
+	require.Equal(1, controller.queues["scheduler"].QueueRef.Len())
+	require.Zero(controller.statuses.Len())
+	require.Empty(stored(t, controller, pr).Status.Phase)
+	require.Empty(stored(t, controller, large).Status.Result)
+}
+
+func TestStatusWorker(t *testing.T) {
+	require := require.New(t)
+
+	large := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b")
+	large.Name = "large"
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	pr.Generation = 3
+	controller := newStatusTestController(t, pr, large)
+	controller.leading.Store(true)
+
+	// the event handlers only push to the queues.
+	controller.enqueue(pr)
+	controller.enqueue(large)
+	require.Equal(1, controller.queues["scheduler"].QueueRef.Len())
+	require.Equal(2, controller.statuses.Len())
+	require.Empty(stored(t, controller, pr).Status.Phase)
+	require.Empty(pr.Status.Phase, "informer objects must not be changed")
+
+	ctx := context.Background()
+	require.True(controller.processNextStatus(ctx))
+	require.True(controller.processNextStatus(ctx))
+	require.Zero(controller.statuses.Len())
+
+	current := stored(t, controller, pr)
+	require.Equal(v1alpha1.PlacementRequestPhaseQueued, current.Status.Phase)
+	require.Equal(int64(3), current.Status.ObservedGeneration)
+	require.NotNil(current.Status.QueuedAt)
+	require.True(meta.IsStatusConditionTrue(current.Status.Conditions, v1alpha1.PlacementRequestConditionQueued))
+
+	rejected := stored(t, controller, large)
+	require.Equal(v1alpha1.PlacementRequestResultRejected, rejected.Status.Result)
+	require.Equal("PlacementRequestTooLarge", rejected.Status.Reason)
+
+	// once the informer sees the new status the queued copy is replaced.
+	current.ResourceVersion = "2"
+	controller.update(pr, current)
+	queued := controller.queues["scheduler"].QueueRef.Pop()
+	require.Equal(v1alpha1.PlacementRequestPhaseQueued, queued.Status.Phase)
+}
+
+func TestStatusWorkerSkipsDequeued(t *testing.T) {
+	require := require.New(t)
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a")
+	controller := newStatusTestController(t, pr)
+	controller.leading.Store(true)
+
+	// the request is served before the worker gets to it, it must not
+	// be marked as queued.
+	controller.enqueue(pr)
+	require.NotNil(controller.queues["scheduler"].QueueRef.Pop())
+	require.True(controller.processNextStatus(context.Background()))
+	require.Empty(stored(t, controller, pr).Status.Phase)
+}
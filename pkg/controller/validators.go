@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/client-go/informers"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+	"kombiner/pkg/validator"
+)
+
+// newValidators returns a validator for each queue with validate plugins
+// enabled, either cluster wide or for the queue itself. Queues without any
+// plugin enabled are not present in the returned map.
+func newValidators(
+	ctx context.Context, cfg configapi.Configuration, configs queue.QueueConfigs, factory informers.SharedInformerFactory,
+) (map[string]*validator.Validator, error) {
+	validators := map[string]*validator.Validator{}
+	for _, qcfg := range configs {
+		plugins := validator.EnabledPlugins(cfg.Plugins, qcfg.Plugins)
+		if len(plugins) == 0 {
+			continue
+		}
+
+		if factory == nil {
+			return nil, fmt.Errorf("validate plugins enabled for %q but no informer factory provided", qcfg.SchedulerName)
+		}
+
+		v, err := validator.New(ctx, plugins, factory)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create validator for %q: %w", qcfg.SchedulerName, err)
+		}
+		validators[qcfg.SchedulerName] = v
+	}
+	return validators, nil
+}
+
+// validate runs the validate plugins of the placement request queue against
+// its bindings. Returns the error for each binding found invalid, indexed by
+// pod name. Bindings for pods that can't be found, or that are already bound,
+// are left for verifyBinding to deal with.
+func (controller *PlacementRequestController) validate(ctx context.Context, pr *v1alpha1.PlacementRequest) map[string]error {
+	v, ok := controller.validators[pr.Spec.SchedulerName]
+	if !ok {
+		return nil
+	}
+
+	var bindings []validator.Binding
+	for _, binding := range pr.Spec.Bindings {
+		pod, err := controller.podlister.Pods(pr.Namespace).Get(binding.PodName)
+		if err != nil || pod.UID != binding.PodUID || pod.Spec.NodeName != "" {
+			continue
+		}
+		bindings = append(bindings, validator.Binding{Pod: pod, NodeName: binding.NodeName})
+	}
+
+	invalid := map[string]error{}
+	for i, err := range v.Validate(ctx, bindings) {
+		if err == nil {
+			continue
+		}
+
+		name := bindings[i].Pod.Name
+		var verr *validator.ValidationError
+		if errors.As(err, &verr) {
+			invalid[name] = &BindingError{Reason: verr.Plugin, Message: verr.Message}
+			continue
+		}
+		invalid[name] = &BindingError{Reason: "Validation error", Message: err.Error()}
+	}
+	return invalid
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+	"kombiner/pkg/queue"
+)
+
+// withTestValidators sets up validators for the test controller queue with
+// the provided plugins enabled. The validators see the same pods and nodes
+// the controller does.
+func withTestValidators(t *testing.T, controller *PlacementRequestController, plugins ...string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	pods, err := controller.podlister.List(labels.Everything())
+	require.NoError(t, err)
+	nodes, err := controller.nodelister.List(labels.Everything())
+	require.NoError(t, err)
+
+	kubecli := kubefake.NewClientset()
+	for _, pod := range pods {
+		require.NoError(t, kubecli.Tracker().Add(pod))
+	}
+	for _, node := range nodes {
+		require.NoError(t, kubecli.Tracker().Add(node))
+	}
+
+	cfg := configapi.Configuration{Plugins: configapi.Plugins{Validate: configapi.PluginSet{Enabled: plugins}}}
+	configs := queue.QueueConfigs{{Queue: configapi.Queue{SchedulerName: "scheduler"}}}
+	factory := informers.NewSharedInformerFactory(kubecli, 0)
+	validators, err := newValidators(ctx, cfg, configs, factory)
+	require.NoError(t, err)
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	controller.validators = validators
+}
+
+func TestNewValidatorsRequiresInformerFactory(t *testing.T) {
+	require := require.New(t)
+
+	configs := queue.QueueConfigs{
+		{Queue: configapi.Queue{SchedulerName: "a"}},
+		{
+			Queue: configapi.Queue{
+				SchedulerName: "b",
+				Plugins:       configapi.Plugins{Validate: configapi.PluginSet{Enabled: []string{"TaintToleration"}}},
+			},
+		},
+	}
+
+	_, err := newValidators(context.Background(), configapi.Configuration{}, configs, nil)
+	require.Error(err)
+
+	factory := informers.NewSharedInformerFactory(kubefake.NewClientset(), 0)
+	validators, err := newValidators(context.Background(), configapi.Configuration{}, configs, factory)
+	require.NoError(err)
+	require.NotContains(validators, "a")
+	require.Contains(validators, "b")
+}
+
+func TestScheduleOneLenientValidation(t *testing.T) {
+	require := require.New(t)
+
+	node := testNode("node")
+	node.Spec.Taints = []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}}
+
+	tolerating := testPod("a")
+	tolerating.Spec.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}
+	pods := []*corev1.Pod{tolerating, testPod("b")}
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyLenient, "a", "b")
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{node})
+	withTestValidators(t, controller, "TaintToleration")
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Equal([]string{"a"}, actions(kubecli, "binding"))
+	require.Equal(
+		map[string]string{
+			"a": "Binding successful",
+			"b": "TaintToleration",
+		},
+		results(pr),
+	)
+}
+
+func TestScheduleOneAllOrNothingValidation(t *testing.T) {
+	require := require.New(t)
+
+	node := testNode("node")
+	node.Spec.Taints = []corev1.Taint{{Key: "dedicated", Effect: corev1.TaintEffectNoSchedule}}
+
+	pr := testPlacementRequest(v1alpha1.PlacementRequestPolicyAllOrNothing, "a", "b")
+	pods := []*corev1.Pod{testPod("a"), testPod("b")}
+	controller, kubecli := newTestController(t, pr, pods, []*corev1.Node{node})
+	withTestValidators(t, controller, "TaintToleration")
+
+	require.NoError(controller.ScheduleOne(context.Background(), pr))
+	pr = stored(t, controller, pr)
+	require.Empty(actions(kubecli, "binding"), "no pod should have been bound")
+	require.Equal(v1alpha1.PlacementRequestResultFailure, pr.Status.Result)
+	require.Equal(
+		map[string]string{
+			"a": "TaintToleration",
+			"b": "TaintToleration",
+		},
+		results(pr),
+	)
+}
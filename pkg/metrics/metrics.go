@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace is the prefix used for all metrics exposed by kombiner.
+const Namespace = "kombiner"
+
+// ExpressAlgorithm is the algorithm label value used for the bindings served
+// out of the express queue, these bypass the fairness algorithm.
+const ExpressAlgorithm = "express"
+
+var (
+	// QueueWaitDuration measures how long placement requests waited since
+	// they were queued until they were popped out of their queue.
+	// Requeued placement requests keep their original queue time.
+	QueueWaitDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "queue_wait_duration_seconds",
+			Help:      "Time placement requests spent waiting since they were queued until popped from their queue.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		},
+		[]string{"scheduler"},
+	)
+
+	// ScheduleOneDuration measures how long it takes to process a single
+	// placement request, from validation to status update.
+	ScheduleOneDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "schedule_one_duration_seconds",
+			Help:      "Time spent processing a single placement request.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+		},
+		[]string{"scheduler", "result"},
+	)
+
+	// BindingResults counts the results of individual bindings by result
+	// and reason.
+	BindingResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "binding_results_total",
+			Help:      "Number of individual pod bindings processed, by result and reason.",
+		},
+		[]string{"scheduler", "result", "reason"},
+	)
+
+	// Rejections counts the rejected placement requests by reason.
+	Rejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "placement_request_rejections_total",
+			Help:      "Number of placement requests rejected, by reason.",
+		},
+		[]string{"scheduler", "reason"},
+	)
+
+	// BindingsServed counts the bindings read out of each queue by the
+	// active fairness algorithm. This is what should be compared against
+	// the configured queue weights. Bindings served out of the express
+	// queue are counted under the ExpressAlgorithm label value.
+	BindingsServed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "bindings_served_total",
+			Help:      "Number of bindings served out of each queue by the fairness algorithm.",
+		},
+		[]string{"scheduler", "algorithm"},
+	)
+
+	// QueueThrottled counts how many times each queue hit its binding rate
+	// limit.
+	QueueThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "queue_throttled_total",
+			Help:      "Number of times a queue was throttled by its binding rate limit.",
+		},
+		[]string{"scheduler"},
+	)
+
+	// queueDepth reports the number of placement requests waiting in each
+	// of the registered queues.
+	queueDepth = &queueDepthCollector{
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "", "queue_depth"),
+			"Number of placement requests waiting in the queue.",
+			[]string{"scheduler"}, nil,
+		),
+		queues: map[string]func() int{},
+	}
+
+	// Registry holds all kombiner metrics alongside the go runtime and
+	// process ones.
+	Registry = prometheus.NewRegistry()
+)
+
+// queueDepthCollector reads the queue lengths at scrape time. Queues are
+// registered through RegisterQueue.
+type queueDepthCollector struct {
+	mtx    sync.Mutex
+	desc   *prometheus.Desc
+	queues map[string]func() int
+}
+
+// Describe sends the collector metric description.
+func (c *queueDepthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect reads the length of every registered queue.
+func (c *queueDepthCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	for name, length := range c.queues {
+		ch <- prometheus.MustNewConstMetric(
+			c.desc, prometheus.GaugeValue, float64(length()), name,
+		)
+	}
+}
+
+// RegisterQueue makes the queue depth metric report the length of a queue.
+// The provided function is called at scrape time. Registering a queue with
+// the same name twice replaces the previous one.
+func RegisterQueue(name string, length func() int) {
+	queueDepth.mtx.Lock()
+	defer queueDepth.mtx.Unlock()
+	queueDepth.queues[name] = length
+}
+
+// Handler returns an http handler serving the metrics in the Registry.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		queueDepth,
+		QueueWaitDuration,
+		ScheduleOneDuration,
+		BindingResults,
+		Rejections,
+		BindingsServed,
+		QueueThrottled,
+	)
+}
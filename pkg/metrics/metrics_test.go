@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueDepth(t *testing.T) {
+	length := 3
+	RegisterQueue("scheduler-a", func() int { return length })
+	RegisterQueue("scheduler-b", func() int { return 1 })
+
+	expected := `
+# HELP kombiner_queue_depth Number of placement requests waiting in the queue.
+# TYPE kombiner_queue_depth gauge
+kombiner_queue_depth{scheduler="scheduler-a"} 3
+kombiner_queue_depth{scheduler="scheduler-b"} 1
+`
+	err := testutil.CollectAndCompare(queueDepth, strings.NewReader(expected))
+	require.NoError(t, err)
+
+	// the length is read at scrape time.
+	length = 7
+	expected = strings.Replace(expected, "} 3", "} 7", 1)
+	err = testutil.CollectAndCompare(queueDepth, strings.NewReader(expected))
+	require.NoError(t, err)
+}
@@ -20,6 +20,9 @@ import (
 	"errors"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"kombiner/pkg/apis/kombiner/v1alpha1"
 )
 
@@ -32,10 +35,10 @@ func Validate(pr *v1alpha1.PlacementRequest) error {
 		return errors.New("the placement request has no bindings")
 	}
 
-	// the current implementation only supports the lenient policy, even
-	// though we still allow these objects to be created with the
-	// AllOrNothing policy.
-	if pr.Spec.Policy != v1alpha1.PlacementRequestPolicyLenient {
+	switch pr.Spec.Policy {
+	case v1alpha1.PlacementRequestPolicyLenient:
+	case v1alpha1.PlacementRequestPolicyAllOrNothing:
+	default:
 		return fmt.Errorf("unsupported policy: %s", pr.Spec.Policy)
 	}
 
@@ -83,7 +86,8 @@ func SetPodBindingResult(
 
 // AssessResult role is to assess, based on the placement request status, if
 // it was successful or not. This function returns the result and a human
-// readable message.
+// readable message. For the AllOrNothing policy anything short of all
+// bindings succeeding is considered a failure.
 func AssessResult(pr *v1alpha1.PlacementRequest) (v1alpha1.PlacementRequestResult, string) {
 	if len(pr.Status.Bindings) == 0 {
 		return v1alpha1.PlacementRequestResultRejected, "No bindings"
@@ -101,6 +105,11 @@ func AssessResult(pr *v1alpha1.PlacementRequest) (v1alpha1.PlacementRequestResul
 		return v1alpha1.PlacementRequestResultFailure, "All bindings failed"
 	case successes == len(pr.Status.Bindings):
 		return v1alpha1.PlacementRequestResultSuccess, "All bindings succeeded"
+	case pr.Spec.Policy == v1alpha1.PlacementRequestPolicyAllOrNothing:
+		msg := fmt.Sprintf(
+			"%d of %d bindings failed", len(pr.Status.Bindings)-successes, len(pr.Status.Bindings),
+		)
+		return v1alpha1.PlacementRequestResultFailure, msg
 	default:
 		msg := fmt.Sprintf(
 			"%d of %d bindings succeeded", successes, len(pr.Status.Bindings),
@@ -108,3 +117,64 @@ func AssessResult(pr *v1alpha1.PlacementRequest) (v1alpha1.PlacementRequestResul
 		return v1alpha1.PlacementRequestResultPartialSuccess, msg
 	}
 }
+
+// SetQueued moves the placement request to the Queued phase. It is called
+// every time the placement request is added to a scheduler queue.
+func SetQueued(pr *v1alpha1.PlacementRequest, now metav1.Time) {
+	pr.Status.Phase = v1alpha1.PlacementRequestPhaseQueued
+	pr.Status.QueuedAt = &now
+	pr.Status.ObservedGeneration = pr.Generation
+	setCondition(pr, v1alpha1.PlacementRequestConditionQueued, metav1.ConditionTrue, "Enqueued", "Waiting in the scheduler queue", now)
+}
+
+// SetBinding moves the placement request to the Binding phase. It is called
+// once the placement request has been dequeued, before any binding happens.
+func SetBinding(pr *v1alpha1.PlacementRequest, now metav1.Time) {
+	pr.Status.Phase = v1alpha1.PlacementRequestPhaseBinding
+	pr.Status.StartedAt = &now
+	pr.Status.ObservedGeneration = pr.Generation
+	setCondition(pr, v1alpha1.PlacementRequestConditionQueued, metav1.ConditionFalse, "Dequeued", "Removed from the scheduler queue", now)
+	setCondition(pr, v1alpha1.PlacementRequestConditionBinding, metav1.ConditionTrue, "BindingStarted", "Processing bindings", now)
+}
+
+// SetCompleted moves the placement request to the Completed phase. The result,
+// reason and message must have already been set in the status as they are
+// reflected in the Completed condition.
+func SetCompleted(pr *v1alpha1.PlacementRequest, now metav1.Time) {
+	pr.Status.Phase = v1alpha1.PlacementRequestPhaseCompleted
+	pr.Status.CompletedAt = &now
+	pr.Status.ObservedGeneration = pr.Generation
+
+	// a placement request may be rejected before it ever reaches a queue
+	// so we only flip the conditions it has already gone through.
+	if meta.FindStatusCondition(pr.Status.Conditions, v1alpha1.PlacementRequestConditionQueued) != nil {
+		setCondition(pr, v1alpha1.PlacementRequestConditionQueued, metav1.ConditionFalse, "Dequeued", "Removed from the scheduler queue", now)
+	}
+	if meta.FindStatusCondition(pr.Status.Conditions, v1alpha1.PlacementRequestConditionBinding) != nil {
+		setCondition(pr, v1alpha1.PlacementRequestConditionBinding, metav1.ConditionFalse, "BindingFinished", "Done processing bindings", now)
+	}
+
+	reason := pr.Status.Reason
+	if reason == "" {
+		reason = string(pr.Status.Result)
+	}
+	setCondition(pr, v1alpha1.PlacementRequestConditionCompleted, metav1.ConditionTrue, reason, pr.Status.Message, now)
+}
+
+// setCondition sets a condition in the placement request status. The last
+// transition time is only changed if the condition status has changed.
+func setCondition(
+	pr *v1alpha1.PlacementRequest, ctype string, status metav1.ConditionStatus, reason, msg string, now metav1.Time,
+) {
+	meta.SetStatusCondition(
+		&pr.Status.Conditions,
+		metav1.Condition{
+			Type:               ctype,
+			Status:             status,
+			ObservedGeneration: pr.Generation,
+			LastTransitionTime: now,
+			Reason:             reason,
+			Message:            msg,
+		},
+	)
+}
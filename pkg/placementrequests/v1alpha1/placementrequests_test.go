@@ -18,10 +18,13 @@ package v1alpha1
 
 import (
 	"testing"
+	"time"
 
 	"kombiner/pkg/apis/kombiner/v1alpha1"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAssessResult(t *testing.T) {
@@ -70,6 +73,23 @@ func TestAssessResult(t *testing.T) {
 			expectedResult:  v1alpha1.PlacementRequestResultPartialSuccess,
 			expectedMessage: "1 of 2 bindings succeeded",
 		},
+		{
+			name: "all or nothing partial success",
+			pr: &v1alpha1.PlacementRequest{
+				Spec: v1alpha1.PlacementRequestSpec{
+					Policy: v1alpha1.PlacementRequestPolicyAllOrNothing,
+				},
+				Status: v1alpha1.PlacementRequestStatus{
+					Bindings: []v1alpha1.PlacementRequestBindingResult{
+						{Result: v1alpha1.PlacementRequestResultSuccess},
+						{Result: v1alpha1.PlacementRequestResultFailure},
+						{Result: v1alpha1.PlacementRequestResultFailure},
+					},
+				},
+			},
+			expectedResult:  v1alpha1.PlacementRequestResultFailure,
+			expectedMessage: "2 of 3 bindings failed",
+		},
 		{
 			name: "no bindings",
 			pr: &v1alpha1.PlacementRequest{
@@ -90,3 +110,96 @@ func TestAssessResult(t *testing.T) {
 		})
 	}
 }
+
+func TestValidate(t *testing.T) {
+	bindings := []v1alpha1.Binding{{PodName: "pod", PodUID: "uid", NodeName: "node"}}
+	tests := []struct {
+		name    string
+		spec    v1alpha1.PlacementRequestSpec
+		wantErr bool
+	}{
+		{
+			name: "lenient",
+			spec: v1alpha1.PlacementRequestSpec{
+				Policy:   v1alpha1.PlacementRequestPolicyLenient,
+				Bindings: bindings,
+			},
+		},
+		{
+			name: "all or nothing",
+			spec: v1alpha1.PlacementRequestSpec{
+				Policy:   v1alpha1.PlacementRequestPolicyAllOrNothing,
+				Bindings: bindings,
+			},
+		},
+		{
+			name: "unknown policy",
+			spec: v1alpha1.PlacementRequestSpec{
+				Policy:   "Unknown",
+				Bindings: bindings,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no bindings",
+			spec: v1alpha1.PlacementRequestSpec{
+				Policy: v1alpha1.PlacementRequestPolicyLenient,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(&v1alpha1.PlacementRequest{Spec: test.spec})
+			require.Equal(t, test.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	require := require.New(t)
+
+	pr := &v1alpha1.PlacementRequest{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+	queued := metav1.NewTime(time.Unix(100, 0))
+	started := metav1.NewTime(time.Unix(200, 0))
+	completed := metav1.NewTime(time.Unix(300, 0))
+
+	SetQueued(pr, queued)
+	require.Equal(v1alpha1.PlacementRequestPhaseQueued, pr.Status.Phase)
+	require.Equal(int64(2), pr.Status.ObservedGeneration)
+	require.True(meta.IsStatusConditionTrue(pr.Status.Conditions, v1alpha1.PlacementRequestConditionQueued))
+
+	SetBinding(pr, started)
+	require.Equal(v1alpha1.PlacementRequestPhaseBinding, pr.Status.Phase)
+	require.False(meta.IsStatusConditionTrue(pr.Status.Conditions, v1alpha1.PlacementRequestConditionQueued))
+	require.True(meta.IsStatusConditionTrue(pr.Status.Conditions, v1alpha1.PlacementRequestConditionBinding))
+
+	pr.Status.Result = v1alpha1.PlacementRequestResultSuccess
+	pr.Status.Message = "All bindings succeeded"
+	SetCompleted(pr, completed)
+	require.Equal(v1alpha1.PlacementRequestPhaseCompleted, pr.Status.Phase)
+	require.False(meta.IsStatusConditionTrue(pr.Status.Conditions, v1alpha1.PlacementRequestConditionBinding))
+
+	cond := meta.FindStatusCondition(pr.Status.Conditions, v1alpha1.PlacementRequestConditionCompleted)
+	require.NotNil(cond)
+	require.Equal(metav1.ConditionTrue, cond.Status)
+	require.Equal("Success", cond.Reason)
+	require.Equal(int64(2), cond.ObservedGeneration)
+
+	require.Equal(queued, *pr.Status.QueuedAt)
+	require.Equal(started, *pr.Status.StartedAt)
+	require.Equal(completed, *pr.Status.CompletedAt)
+}
+
+func TestSetCompletedOnRejection(t *testing.T) {
+	require := require.New(t)
+
+	pr := &v1alpha1.PlacementRequest{}
+	pr.Status.Result = v1alpha1.PlacementRequestResultRejected
+	pr.Status.Reason = "QueueNotFound"
+	SetCompleted(pr, metav1.Now())
+
+	require.Len(pr.Status.Conditions, 1, "a rejected request never went through a queue")
+	require.Equal("QueueNotFound", pr.Status.Conditions[0].Reason)
+}
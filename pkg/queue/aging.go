@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"math"
+	"time"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// WithAging makes the priority of the PlacementRequests in the queue rise
+// with the time they spend waiting.
+func WithAging(aging *Aging) PlacementRequestQueueOption {
+	return func(q *PlacementRequestQueue) {
+		q.aging = aging
+	}
+}
+
+// Aging defines how the priority of a PlacementRequest rises while it waits
+// in a queue. The effective priority of a PlacementRequest is its priority
+// plus Rate times the seconds it has been queued. As all PlacementRequests
+// in a queue age at the same rate we don't need to reorder the queue as time
+// passes: we order by the priority each PlacementRequest would have had when
+// the queue was created (its score) and add the time elapsed since then.
+type Aging struct {
+	// Rate is the amount of priority gained per second spent queued.
+	Rate float64
+
+	// MaxWait is the maximum time a PlacementRequest may wait. Once it
+	// has waited this long it is served next or, if Reject is set, it
+	// is handed over through PopExpired. Zero means no limit.
+	MaxWait time.Duration
+
+	// Reject makes the PlacementRequests that waited for too long to be
+	// rejected instead of served.
+	Reject bool
+}
+
+// score returns the priority the PlacementRequest would have had at the
+// queue epoch. A nil Aging simply returns the PlacementRequest priority.
+func (a *Aging) score(pr *v1alpha1.PlacementRequest, queuedAt, epoch time.Time) float64 {
+	priority := float64(pr.Spec.Priority)
+	if a == nil {
+		return priority
+	}
+	return priority - a.Rate*queuedAt.Sub(epoch).Seconds()
+}
+
+// effective returns the effective priority, at the provided time, for the
+// provided score.
+func (a *Aging) effective(score float64, now, epoch time.Time) int64 {
+	if a == nil {
+		return int64(math.Floor(score))
+	}
+	return int64(math.Floor(score + a.Rate*now.Sub(epoch).Seconds()))
+}
+
+// serveExpired returns true if PlacementRequests waiting for too long must
+// be served next.
+func (a *Aging) serveExpired() bool {
+	return a != nil && a.MaxWait > 0 && !a.Reject
+}
+
+// rejectExpired returns true if PlacementRequests waiting for too long must
+// be rejected.
+func (a *Aging) rejectExpired() bool {
+	return a != nil && a.MaxWait > 0 && a.Reject
+}
+
+// NewAgingFromV1Alpha1 converts the priority aging of a queue as present in
+// the controller configuration. Returns nil if the provided aging is nil.
+func NewAgingFromV1Alpha1(cfg *configapi.PriorityAging) *Aging {
+	if cfg == nil {
+		return nil
+	}
+
+	aging := &Aging{Reject: cfg.MaxWaitAction == configapi.MaxWaitActionReject}
+	if cfg.Interval.Duration > 0 {
+		aging.Rate = float64(cfg.Rate) / cfg.Interval.Seconds()
+	}
+	if cfg.MaxWait != nil {
+		aging.MaxWait = cfg.MaxWait.Duration
+	}
+	return aging
+}
+
+// arrival wraps a PrioritizedPlacementRequest so it is ordered by the time
+// it was queued instead of by priority.
+type arrival struct {
+	*PrioritizedPlacementRequest
+}
+
+// Priority returns the same priority for all arrivals, they are ordered by
+// Before alone.
+func (a *arrival) Priority() int64 {
+	return 0
+}
+
+// Before returns true if this PlacementRequest was queued before the other
+// one, falling back to the order in which they were pushed.
+func (a *arrival) Before(other Prioritized) bool {
+	o, ok := other.(*arrival)
+	if !ok {
+		panic("cannot compare arrival with a different type")
+	}
+	if !a.queuedAt.Equal(o.queuedAt) {
+		return a.queuedAt.Before(o.queuedAt)
+	}
+	return a.sequence < o.sequence
+}
+
+// This global variable ensure that arrival implements the Prioritized
+// interface.
+var _ Prioritized = &arrival{}
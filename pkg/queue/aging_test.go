@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// agedPlacementRequest returns a placement request with the provided priority
+// queued at the provided time.
+func agedPlacementRequest(name string, priority int32, queuedAt time.Time) *v1alpha1.PlacementRequest {
+	pr := sizedPlacementRequest("a", 0, 1)
+	pr.Name = name
+	pr.Spec.Priority = v1alpha1.PlacementRequestPriority(priority)
+	pr.Status.QueuedAt = &metav1.Time{Time: queuedAt}
+	return pr
+}
+
+// agingQueue returns a queue with the provided aging whose clock is fixed at
+// the returned time.
+func agingQueue(aging *Aging) (*PlacementRequestQueue, time.Time) {
+	now := time.Now()
+	queue := NewPlacementRequestQueue(WithAging(aging))
+	queue.now = func() time.Time { return now }
+	return queue, now
+}
+
+func TestPlacementRequestQueueAging(t *testing.T) {
+	require := require.New(t)
+
+	// without aging the priority is all that matters.
+	queue, now := agingQueue(nil)
+	queue.Push(agedPlacementRequest("old", 0, now.Add(-time.Minute)))
+	queue.Push(agedPlacementRequest("new", 10, now))
+	require.Equal("new", queue.Pop().Name)
+	require.Equal("old", queue.Pop().Name)
+
+	// gaining one priority point per second the old request has an
+	// effective priority of 60 against 10 of the new one.
+	queue, now = agingQueue(&Aging{Rate: 1})
+	queue.Push(agedPlacementRequest("new", 10, now))
+	queue.Push(agedPlacementRequest("old", 0, now.Add(-time.Minute)))
+	queue.Push(agedPlacementRequest("mid", 20, now.Add(-5*time.Second)))
+
+	dump := queue.Dump()
+	require.Len(dump, 3)
+	require.Equal("/old", dump[0].Key)
+	require.Equal(int32(0), dump[0].Priority)
+	require.InDelta(60, dump[0].EffectivePriority, 1)
+	require.Equal("/mid", dump[1].Key)
+	require.InDelta(25, dump[1].EffectivePriority, 1)
+	require.Equal("/new", dump[2].Key)
+	require.InDelta(10, dump[2].EffectivePriority, 1)
+
+	require.Equal("old", queue.Pop().Name)
+	require.Equal("mid", queue.Pop().Name)
+	require.Equal("new", queue.Pop().Name)
+}
+
+func TestPlacementRequestQueueMaxWaitServe(t *testing.T) {
+	require := require.New(t)
+
+	queue, now := agingQueue(&Aging{MaxWait: time.Minute})
+	queue.Push(agedPlacementRequest("high", 100, now))
+	queue.Push(agedPlacementRequest("recent", 0, now.Add(-30*time.Second)))
+	queue.Push(agedPlacementRequest("expired", 0, now.Add(-2*time.Minute)))
+
+	require.Empty(queue.PopExpired())
+	require.Equal("expired", queue.Peek().Name)
+	require.Equal("expired", queue.Pop().Name)
+	require.Equal("high", queue.Pop().Name)
+	require.Equal("recent", queue.Pop().Name)
+}
+
+func TestPlacementRequestQueueMaxWaitReject(t *testing.T) {
+	require := require.New(t)
+
+	queue, now := agingQueue(&Aging{MaxWait: time.Minute, Reject: true})
+	queue.Push(agedPlacementRequest("high", 100, now))
+	queue.Push(agedPlacementRequest("expired", 0, now.Add(-2*time.Minute)))
+	queue.Push(agedPlacementRequest("older", 0, now.Add(-3*time.Minute)))
+
+	var names []string
+	for _, pr := range queue.PopExpired() {
+		names = append(names, pr.Name)
+	}
+	require.Equal([]string{"older", "expired"}, names)
+	require.Equal(1, queue.Len())
+	require.Equal("high", queue.Pop().Name)
+	require.Empty(queue.PopExpired())
+}
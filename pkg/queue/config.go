@@ -104,8 +104,12 @@ func QueueConfigFromV1Alpha1Config(raw v1alpha1.Configuration) QueueConfigs {
 	for _, config := range raw.Queues {
 		configs = append(
 			configs, QueueConfig{
-				Queue:    config,
-				QueueRef: NewPlacementRequestQueue(),
+				Queue: config,
+				QueueRef: NewPlacementRequestQueue(
+					WithTenancy(NewTenancyFromV1Alpha1(config.Tenancy)),
+					WithAging(NewAgingFromV1Alpha1(config.PriorityAging)),
+					WithRateLimit(NewRateLimiterFromV1Alpha1(config)),
+				),
 			},
 		)
 	}
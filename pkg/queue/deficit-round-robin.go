@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"math"
+	"slices"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// DeficitQueueConfig extends a QueueConfig with the quantum of bindings the
+// queue is granted on each round and its current deficit, i.e. how many
+// bindings it can still be served.
+type DeficitQueueConfig struct {
+	QueueConfig
+	Quantum int
+	Deficit int
+}
+
+// DeficitRoundRobinReader implements the Deficit Round Robin algorithm. The
+// queues are visited in order, on each visit the queue deficit is increased
+// by its quantum and PlacementRequests are read from it for as long as they
+// fit in the deficit. Contrary to the RoundRobinReader a PlacementRequest is
+// never read if it does not fit, the deficit is instead carried over to the
+// next round. This way large PlacementRequests do not take more than the
+// queue share. The deficit is zeroed once a queue is found empty so queues
+// can't save up while idle. If a FairnessState is provided the initial deficits
+// are taken from it, bounded to a quantum either way.
+type DeficitRoundRobinReader struct {
+	configs  []DeficitQueueConfig
+	current  int
+	visiting bool
+}
+
+// This global variable is used to ensure that the DeficitRoundRobinReader
+// implements the StatefulReader interface.
+var _ StatefulReader = &DeficitRoundRobinReader{}
+
+// SetFairnessState sets the initial deficits out of the bindings each queue
+// is owed according to the fairness history.
+func (r *DeficitRoundRobinReader) SetFairnessState(state *FairnessState) {
+	configs := make([]QueueConfig, len(r.configs))
+	for i, cfg := range r.configs {
+		configs[i] = cfg.QueueConfig
+	}
+
+	owed := state.Owed(configs)
+	for i, cfg := range r.configs {
+		r.configs[i].Deficit = min(max(owed[cfg.SchedulerName], -cfg.Quantum), cfg.Quantum)
+	}
+}
+
+// Read returns the next PlacementRequest according to the deficit of each
+// queue. Returns nil if all queues are empty.
+func (r *DeficitRoundRobinReader) Read(_ context.Context) *v1alpha1.PlacementRequest {
+	for {
+		// we go back to the first queue only after a full round, this
+		// is a good moment to check if there is anything left to read.
+		if r.current == 0 && !r.visiting && r.empty() {
+			return nil
+		}
+
+		cfg := &r.configs[r.current]
+		if !r.visiting {
+			cfg.Deficit += cfg.Quantum
+			r.visiting = true
+		}
+
+		pr := cfg.QueueRef.PopIf(
+			func(pr *v1alpha1.PlacementRequest) bool {
+				return len(pr.Spec.Bindings) <= cfg.Deficit
+			},
+		)
+		if pr != nil {
+			cfg.Deficit -= len(pr.Spec.Bindings)
+			return pr
+		}
+
+		// either the queue is empty (or throttled) or the next
+		// PlacementRequest does not fit. in the first case the queue
+		// loses its deficit.
+		if !cfg.QueueRef.Ready() {
+			cfg.Deficit = 0
+		}
+		r.advance()
+	}
+}
+
+// advance moves to the next queue.
+func (r *DeficitRoundRobinReader) advance() {
+	r.current = (r.current + 1) % len(r.configs)
+	r.visiting = false
+}
+
+// empty returns true if all queues are empty or throttled.
+func (r *DeficitRoundRobinReader) empty() bool {
+	for _, cfg := range r.configs {
+		if cfg.QueueRef.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// NewDeficitRoundRobinReader creates a new DeficitRoundRobinReader for the
+// provided queue configurations. Queues without an explicit quantum get one
+// relative to their weight, the lighter queue gets MinimumBindings. This
+// function expects the configuration to be properly sanitized before
+// entering here, it panics with invalid data.
+func NewDeficitRoundRobinReader(configs QueueConfigs) Reader {
+	lighter := slices.MinFunc(
+		configs,
+		func(a, b QueueConfig) int {
+			return int(a.Weight) - int(b.Weight)
+		},
+	)
+
+	if lighter.Weight == 0 {
+		panic("queue with zero weight provided")
+	}
+
+	extended := make([]DeficitQueueConfig, len(configs))
+	for i, cfg := range configs {
+		quantum := int(cfg.Quantum)
+		if quantum == 0 {
+			multiplier := float64(cfg.Weight) / float64(lighter.Weight)
+			quantum = int(math.Ceil(multiplier * float64(MinimumBindings)))
+		}
+		extended[i] = DeficitQueueConfig{
+			QueueConfig: cfg,
+			Quantum:     quantum,
+		}
+	}
+
+	return &DeficitRoundRobinReader{
+		configs: extended,
+	}
+}
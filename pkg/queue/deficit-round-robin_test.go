@@ -0,0 +1,140 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	configv1alpha1 "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// sizedPlacementRequest returns a placement request with the provided amount
+// of bindings.
+func sizedPlacementRequest(scheduler string, idx, size int) *v1alpha1.PlacementRequest {
+	pr := &v1alpha1.PlacementRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("%s-%d", scheduler, idx)},
+		Spec:       v1alpha1.PlacementRequestSpec{SchedulerName: scheduler},
+	}
+	for i := range size {
+		pr.Spec.Bindings = append(pr.Spec.Bindings, v1alpha1.Binding{PodName: fmt.Sprintf("pod-%d", i)})
+	}
+	return pr
+}
+
+func TestDeficitRoundRobinReaderOversized(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "gangs", Weight: 1, Quantum: 10},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "singles", Weight: 1, Quantum: 10},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	for i := range 100 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("gangs", i, 25))
+		configs[1].QueueRef.Push(sizedPlacementRequest("singles", i, 1))
+	}
+
+	// the gangs queue needs three rounds to save up for a request, the
+	// singles queue is served ten requests per round in the meantime.
+	reader := NewDeficitRoundRobinReader(configs)
+	var order []string
+	for range 31 {
+		pr := reader.Read(context.Background())
+		require.NotNil(pr)
+		order = append(order, pr.Spec.SchedulerName)
+	}
+
+	expected := []string{}
+	for range 20 {
+		expected = append(expected, "singles")
+	}
+	expected = append(expected, "gangs")
+	for range 10 {
+		expected = append(expected, "singles")
+	}
+	require.Equal(expected, order)
+}
+
+func TestDeficitRoundRobinReaderShare(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 3},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "b", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	// queue "a" holds requests larger than its quantum (30 bindings).
+	for i := range 1000 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 40))
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", i, 3))
+	}
+
+	reader := NewDeficitRoundRobinReader(configs)
+	served := map[string]int{}
+	for range 400 {
+		pr := reader.Read(context.Background())
+		require.NotNil(pr)
+		served[pr.Spec.SchedulerName] += len(pr.Spec.Bindings)
+	}
+
+	// over the long run the bindings served must follow the weights, we
+	// allow the difference of a single request.
+	ratio := float64(served["a"]) / float64(served["b"])
+	require.InDelta(3.0, ratio, 0.2, "unexpected share: %v", served)
+}
+
+func TestDeficitRoundRobinReaderEmpty(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "b", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	reader := NewDeficitRoundRobinReader(configs)
+	require.Nil(reader.Read(context.Background()))
+
+	configs[1].QueueRef.Push(sizedPlacementRequest("b", 0, 50))
+	pr := reader.Read(context.Background())
+	require.NotNil(pr, "oversized requests must eventually be read")
+	require.Equal("b-0", pr.Name)
+	require.Nil(reader.Read(context.Background()))
+}
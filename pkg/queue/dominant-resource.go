@@ -0,0 +1,192 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"math"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// ResourceLookup gives the DominantResourceReader access to the cluster. It
+// is used to find out the resources requested by a PlacementRequest and the
+// total amount of resources in the cluster.
+type ResourceLookup interface {
+	// Capacity returns the total amount of each resource in the cluster.
+	Capacity() corev1.ResourceList
+
+	// Requests returns the sum of the resources requested by all pods in
+	// the PlacementRequest.
+	Requests(*v1alpha1.PlacementRequest) corev1.ResourceList
+}
+
+// DominantResourceUsage holds the state of the DominantResourceReader: the
+// resources each queue has been charged with, the offset added to the share
+// of the queues that came back from idle and which queues are idle. It
+// outlives the readers so the dominant shares are not reset when the
+// QueueIterator creates a new reader.
+type DominantResourceUsage struct {
+	Charged map[string]map[corev1.ResourceName]float64
+	Offset  map[string]float64
+	Idle    map[string]bool
+}
+
+// DominantResourceReader implements Dominant Resource Fairness among queues.
+// Each queue is charged with the resources requested by the pods it has been
+// served, its dominant share is the largest fraction of a cluster resource it
+// has been charged with. The next PlacementRequest is read from the queue with
+// the lowest dominant share divided by its weight. A queue coming back from
+// idle starts at the lowest share among the busy queues so it can't claim all
+// the time it spent idle.
+type DominantResourceReader struct {
+	configs  QueueConfigs
+	lookup   ResourceLookup
+	capacity corev1.ResourceList
+	usage    *DominantResourceUsage
+}
+
+// Read returns the next PlacementRequest from the queue with the lowest
+// weighted dominant share. Returns nil if all queues are empty.
+func (d *DominantResourceReader) Read(_ context.Context) *v1alpha1.PlacementRequest {
+	for {
+		shares := map[string]float64{}
+		lowest, returning := math.Inf(1), []string{}
+		for _, cfg := range d.configs {
+			name := cfg.SchedulerName
+			// throttled queues are seen as idle, once they are
+			// back they can't claim the time they were throttled.
+			if !cfg.QueueRef.Ready() {
+				d.usage.Idle[name] = true
+				continue
+			}
+
+			shares[name] = d.share(cfg) + d.usage.Offset[name]
+			if d.usage.Idle[name] {
+				returning = append(returning, name)
+				continue
+			}
+			lowest = min(lowest, shares[name])
+		}
+
+		if len(shares) == 0 {
+			return nil
+		}
+
+		// queues coming back from idle are moved up to where the
+		// busy queues are, if there are no busy queues there is
+		// nothing to catch up with.
+		for _, name := range returning {
+			if !math.IsInf(lowest, 1) && shares[name] < lowest {
+				d.usage.Offset[name] += lowest - shares[name]
+				shares[name] = lowest
+			}
+			d.usage.Idle[name] = false
+		}
+
+		selected := -1
+		for i, cfg := range d.configs {
+			share, ok := shares[cfg.SchedulerName]
+			if !ok {
+				continue
+			}
+			if selected < 0 || share < shares[d.configs[selected].SchedulerName] {
+				selected = i
+			}
+		}
+
+		// the queue may have been drained since we looked at it, if
+		// so we just start over.
+		pr := d.configs[selected].QueueRef.Pop()
+		if pr == nil {
+			continue
+		}
+
+		d.charge(d.configs[selected].SchedulerName, pr)
+		return pr
+	}
+}
+
+// share returns the dominant share of the provided queue divided by the
+// queue weight.
+func (d *DominantResourceReader) share(cfg QueueConfig) float64 {
+	var dominant float64
+	for name, used := range d.usage.Charged[cfg.SchedulerName] {
+		capacity, ok := d.capacity[name]
+		if !ok || capacity.IsZero() {
+			continue
+		}
+		dominant = max(dominant, used/capacity.AsApproximateFloat64())
+	}
+	return dominant / float64(cfg.Weight)
+}
+
+// charge adds the resources requested by the PlacementRequest to the queue
+// usage.
+func (d *DominantResourceReader) charge(queue string, pr *v1alpha1.PlacementRequest) {
+	usage, ok := d.usage.Charged[queue]
+	if !ok {
+		usage = map[corev1.ResourceName]float64{}
+		d.usage.Charged[queue] = usage
+	}
+
+	for name, quantity := range d.lookup.Requests(pr) {
+		usage[name] += quantity.AsApproximateFloat64()
+	}
+}
+
+// NewDominantResourceReader creates a DominantResourceReader for the provided
+// queues using the provided usage. The cluster capacity is read once, when the
+// reader is created.
+func NewDominantResourceReader(configs QueueConfigs, lookup ResourceLookup, usage *DominantResourceUsage) Reader {
+	if usage.Charged == nil {
+		usage.Charged = map[string]map[corev1.ResourceName]float64{}
+	}
+	if usage.Offset == nil {
+		usage.Offset = map[string]float64{}
+	}
+	if usage.Idle == nil {
+		usage.Idle = map[string]bool{}
+	}
+
+	for _, cfg := range configs {
+		// this should never happen as configs are validated.
+		if cfg.Weight == 0 {
+			panic("queue with zero weight provided")
+		}
+	}
+
+	return &DominantResourceReader{
+		configs:  configs,
+		lookup:   lookup,
+		capacity: lookup.Capacity(),
+		usage:    usage,
+	}
+}
+
+// NewDominantResourceReaderFactory returns a ReaderFactory that creates
+// DominantResourceReaders using the provided ResourceLookup. The readers share
+// the same usage so the dominant shares are kept across the multiple readers
+// a QueueIterator creates.
+func NewDominantResourceReaderFactory(lookup ResourceLookup) ReaderFactory {
+	usage := &DominantResourceUsage{}
+	return func(configs QueueConfigs) Reader {
+		return NewDominantResourceReader(configs, lookup, usage)
+	}
+}
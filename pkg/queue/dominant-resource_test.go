@@ -0,0 +1,238 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	configv1alpha1 "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// fakeLookup charges every binding with the resources configured for the
+// scheduler that created the placement request.
+type fakeLookup struct {
+	capacity corev1.ResourceList
+	perpod   map[string]corev1.ResourceList
+}
+
+func (f *fakeLookup) Capacity() corev1.ResourceList {
+	return f.capacity
+}
+
+func (f *fakeLookup) Requests(pr *v1alpha1.PlacementRequest) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for range pr.Spec.Bindings {
+		for name, quantity := range f.perpod[pr.Spec.SchedulerName] {
+			current := requests[name]
+			current.Add(quantity)
+			requests[name] = current
+		}
+	}
+	return requests
+}
+
+func TestDominantResourceReader(t *testing.T) {
+	require := require.New(t)
+
+	lookup := &fakeLookup{
+		capacity: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1000"),
+			corev1.ResourceMemory: resource.MustParse("1000Gi"),
+			"nvidia.com/gpu":      resource.MustParse("100"),
+		},
+		perpod: map[string]corev1.ResourceList{
+			// each pod takes 4% of the gpus.
+			"gpu": {
+				corev1.ResourceCPU: resource.MustParse("1"),
+				"nvidia.com/gpu":   resource.MustParse("4"),
+			},
+			// each pod takes 1% of the cpu.
+			"cpu": {
+				corev1.ResourceCPU:    resource.MustParse("10"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+		},
+	}
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "gpu", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "cpu", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	for i := range 100 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("gpu", i, 1))
+		configs[1].QueueRef.Push(sizedPlacementRequest("cpu", i, 1))
+	}
+
+	reader := NewDominantResourceReader(configs, lookup, &DominantResourceUsage{})
+	served := map[string]int{}
+	for range 50 {
+		pr := reader.Read(context.Background())
+		require.NotNil(pr)
+		served[pr.Spec.SchedulerName]++
+	}
+
+	// a gpu pod weights four times a cpu one, so the cpu queue gets four
+	// requests for each one the gpu queue gets.
+	require.Equal(map[string]int{"gpu": 10, "cpu": 40}, served)
+}
+
+func TestDominantResourceReaderWeights(t *testing.T) {
+	require := require.New(t)
+
+	lookup := &fakeLookup{
+		capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100")},
+		perpod: map[string]corev1.ResourceList{
+			"a": {corev1.ResourceCPU: resource.MustParse("1")},
+			"b": {corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 3},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "b", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	for i := range 100 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 1))
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", i, 1))
+	}
+
+	reader := NewDominantResourceReader(configs, lookup, &DominantResourceUsage{})
+	served := map[string]int{}
+	for range 40 {
+		served[reader.Read(context.Background()).Spec.SchedulerName]++
+	}
+	require.Equal(map[string]int{"a": 30, "b": 10}, served)
+}
+
+func TestDominantResourceReaderIdleQueue(t *testing.T) {
+	require := require.New(t)
+
+	lookup := &fakeLookup{
+		capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100")},
+		perpod: map[string]corev1.ResourceList{
+			"a": {corev1.ResourceCPU: resource.MustParse("1")},
+			"b": {corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "b", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	for i := range 100 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 1))
+	}
+
+	reader := NewDominantResourceReader(configs, lookup, &DominantResourceUsage{})
+	for range 20 {
+		require.Equal("a", reader.Read(context.Background()).Spec.SchedulerName)
+	}
+
+	// "b" was idle while "a" was served, once it gets some work it must
+	// not get all the reads until it catches up.
+	for i := range 100 {
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", i, 1))
+	}
+
+	served := map[string]int{}
+	for range 20 {
+		served[reader.Read(context.Background()).Spec.SchedulerName]++
+	}
+	require.Equal(map[string]int{"a": 10, "b": 10}, served)
+
+	for _, cfg := range configs {
+		cfg.QueueRef.Clear()
+	}
+	require.Nil(reader.Read(context.Background()))
+}
+
+func TestDominantResourceReaderFactoryKeepsUsage(t *testing.T) {
+	require := require.New(t)
+
+	lookup := &fakeLookup{
+		capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100")},
+		perpod: map[string]corev1.ResourceList{
+			"a": {corev1.ResourceCPU: resource.MustParse("1")},
+			"b": {corev1.ResourceCPU: resource.MustParse("1")},
+		},
+	}
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "b", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	// "a" is served ten requests more than "b".
+	factory := NewDominantResourceReaderFactory(lookup)
+	for i := range 20 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 1))
+	}
+	for i := range 10 {
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", i, 1))
+	}
+
+	first := factory(configs)
+	for range 30 {
+		require.NotNil(first.Read(context.Background()))
+	}
+	require.Nil(first.Read(context.Background()))
+
+	// a new reader, as created when the iterator resumes, carries on
+	// from where the previous one stopped so "b" gets to catch up.
+	for i := range 10 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", 100+i, 1))
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", 100+i, 1))
+	}
+
+	second := factory(configs)
+	for range 10 {
+		require.Equal("b", second.Read(context.Background()).Spec.SchedulerName)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"sync"
+	"time"
+)
+
+// FairnessBuckets is the number of buckets the fairness window is split into.
+// Bindings expire from the window one bucket at a time.
+const FairnessBuckets = 60
+
+// FairnessBucket holds the amount of bindings served per scheduler during a
+// slice of the fairness window.
+type FairnessBucket struct {
+	Start    time.Time      `json:"start"`
+	Bindings map[string]int `json:"bindings"`
+}
+
+// FairnessSnapshot is a serializable copy of a FairnessState. It is used to
+// save the state somewhere and restore it later on.
+type FairnessSnapshot struct {
+	Buckets []FairnessBucket `json:"buckets"`
+}
+
+// FairnessState keeps track of the bindings served for each scheduler over a
+// sliding time window. It outlives the readers the QueueIterator creates so
+// fairness can be measured over a longer period than a single burst. Readers
+// implementing StatefulReader use it to figure out which schedulers are owed
+// bandwidth and which ones have been using more than their share. This is
+// safe for concurrent use.
+type FairnessState struct {
+	mtx     sync.Mutex
+	window  time.Duration
+	buckets []FairnessBucket
+	now     func() time.Time
+}
+
+// StatefulReader is a Reader that takes the fairness history into account.
+// The QueueIterator hands its FairnessState over to the readers implementing
+// this interface right after creating them.
+type StatefulReader interface {
+	Reader
+	SetFairnessState(*FairnessState)
+}
+
+// Record accounts for the bindings served for the provided scheduler.
+func (f *FairnessState) Record(scheduler string, bindings int) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	now := f.now()
+	f.expire(now)
+
+	width := f.window / FairnessBuckets
+	if n := len(f.buckets); n == 0 || now.Sub(f.buckets[n-1].Start) >= width {
+		f.buckets = append(f.buckets, FairnessBucket{Start: now, Bindings: map[string]int{}})
+	}
+	f.buckets[len(f.buckets)-1].Bindings[scheduler] += bindings
+}
+
+// Usage returns the amount of bindings served per scheduler within the window.
+func (f *FairnessState) Usage() map[string]int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.expire(f.now())
+	usage := map[string]int{}
+	for _, bucket := range f.buckets {
+		for scheduler, bindings := range bucket.Bindings {
+			usage[scheduler] += bindings
+		}
+	}
+	return usage
+}
+
+// Owed returns, for each of the provided queues, how many bindings it is owed
+// within the window. This is the difference between the share its weight
+// entitles it to and what it has actually been served. Queues that have been
+// served more than their share get a negative value.
+func (f *FairnessState) Owed(configs []QueueConfig) map[string]int {
+	usage := f.Usage()
+
+	var total, weights int
+	for _, cfg := range configs {
+		total += usage[cfg.SchedulerName]
+		weights += int(cfg.Weight)
+	}
+
+	owed := map[string]int{}
+	for _, cfg := range configs {
+		if weights == 0 {
+			continue
+		}
+		share := total * int(cfg.Weight) / weights
+		owed[cfg.SchedulerName] = share - usage[cfg.SchedulerName]
+	}
+	return owed
+}
+
+// Snapshot returns a copy of the state that can be saved and later restored.
+func (f *FairnessState) Snapshot() FairnessSnapshot {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.expire(f.now())
+	snapshot := FairnessSnapshot{Buckets: make([]FairnessBucket, 0, len(f.buckets))}
+	for _, bucket := range f.buckets {
+		bindings := make(map[string]int, len(bucket.Bindings))
+		for scheduler, n := range bucket.Bindings {
+			bindings[scheduler] = n
+		}
+		snapshot.Buckets = append(snapshot.Buckets, FairnessBucket{Start: bucket.Start, Bindings: bindings})
+	}
+	return snapshot
+}
+
+// Restore replaces the state with the content of a snapshot. Buckets that
+// have already left the window are discarded.
+func (f *FairnessState) Restore(snapshot FairnessSnapshot) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	f.buckets = nil
+	for _, bucket := range snapshot.Buckets {
+		if bucket.Bindings == nil {
+			bucket.Bindings = map[string]int{}
+		}
+		f.buckets = append(f.buckets, bucket)
+	}
+	f.expire(f.now())
+}
+
+// expire drops the buckets that started before the window. Callers must hold
+// the lock.
+func (f *FairnessState) expire(now time.Time) {
+	cutoff := now.Add(-f.window)
+	idx := 0
+	for idx < len(f.buckets) && f.buckets[idx].Start.Before(cutoff) {
+		idx++
+	}
+	f.buckets = f.buckets[idx:]
+}
+
+// NewFairnessState returns a FairnessState keeping track of the bindings
+// served during the provided window.
+func NewFairnessState(window time.Duration) *FairnessState {
+	return &FairnessState{
+		window: window,
+		now:    time.Now,
+	}
+}
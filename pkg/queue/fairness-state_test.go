@@ -0,0 +1,130 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	configv1alpha1 "kombiner/pkg/apis/config/v1alpha1"
+)
+
+// fakeClockState returns a FairnessState whose clock is controlled by the
+// returned pointer.
+func fakeClockState(window time.Duration) (*FairnessState, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := NewFairnessState(window)
+	state.now = func() time.Time { return now }
+	return state, &now
+}
+
+func TestFairnessStateExpire(t *testing.T) {
+	require := require.New(t)
+
+	state, now := fakeClockState(time.Hour)
+	state.Record("a", 10)
+	*now = now.Add(30 * time.Minute)
+	state.Record("a", 5)
+	state.Record("b", 3)
+	require.Equal(map[string]int{"a": 15, "b": 3}, state.Usage())
+
+	*now = now.Add(45 * time.Minute)
+	require.Equal(map[string]int{"a": 5, "b": 3}, state.Usage())
+
+	*now = now.Add(time.Hour)
+	require.Empty(state.Usage())
+}
+
+func TestFairnessStateOwed(t *testing.T) {
+	require := require.New(t)
+
+	configs := []QueueConfig{
+		{Queue: configv1alpha1.Queue{SchedulerName: "a", Weight: 1}},
+		{Queue: configv1alpha1.Queue{SchedulerName: "b", Weight: 3}},
+	}
+
+	state, _ := fakeClockState(time.Hour)
+	require.Equal(map[string]int{"a": 0, "b": 0}, state.Owed(configs))
+
+	state.Record("a", 60)
+	state.Record("b", 20)
+	require.Equal(map[string]int{"a": -40, "b": 40}, state.Owed(configs))
+}
+
+func TestFairnessStateSnapshotRestore(t *testing.T) {
+	require := require.New(t)
+
+	state, now := fakeClockState(time.Hour)
+	state.Record("a", 10)
+	*now = now.Add(50 * time.Minute)
+	state.Record("b", 5)
+
+	data, err := json.Marshal(state.Snapshot())
+	require.NoError(err)
+
+	var snapshot FairnessSnapshot
+	require.NoError(json.Unmarshal(data, &snapshot))
+
+	restored, later := fakeClockState(time.Hour)
+	*later = *now
+	restored.Restore(snapshot)
+	require.Equal(map[string]int{"a": 10, "b": 5}, restored.Usage())
+
+	// by the time this snapshot is restored the first bucket is gone.
+	restored, later = fakeClockState(time.Hour)
+	*later = now.Add(30 * time.Minute)
+	restored.Restore(snapshot)
+	require.Equal(map[string]int{"b": 5}, restored.Usage())
+}
+
+func TestRoundRobinReaderFairnessState(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{Queue: configv1alpha1.Queue{SchedulerName: "a", Weight: 1}, QueueRef: NewPlacementRequestQueue()},
+		{Queue: configv1alpha1.Queue{SchedulerName: "b", Weight: 1}, QueueRef: NewPlacementRequestQueue()},
+	}
+	for i := range 30 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 1))
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", i, 1))
+	}
+
+	// scheduler a has been served way more than b, b should then be
+	// given two rounds worth of bindings before a is read again.
+	state, _ := fakeClockState(time.Hour)
+	state.Record("a", 100)
+
+	reader := NewRoundRobinReader(configs).(*RoundRobinReader)
+	reader.SetFairnessState(state)
+
+	var got []string
+	for range 20 {
+		pr := reader.Read(context.Background())
+		require.NotNil(pr)
+		got = append(got, pr.Spec.SchedulerName)
+	}
+
+	expected := []string{"a"}
+	for range 19 {
+		expected = append(expected, "b")
+	}
+	require.Equal(expected, got)
+}
@@ -28,3 +28,21 @@ func WithReaderFactory(factory ReaderFactory) QueueIteratorOption {
 		q.readerFactory = factory
 	}
 }
+
+// WithFairnessState sets the state used to keep track of the bindings served
+// for each scheduler over time. Without it readers only see what happens
+// while the queues are busy.
+func WithFairnessState(state *FairnessState) QueueIteratorOption {
+	return func(q *QueueIterator) {
+		q.state = state
+	}
+}
+
+// WithExpressQueue sets a queue that is always read before the ones handled
+// by the fairness algorithm. The queue should have a rate limit (see
+// WithRateLimit) so it can't starve the other queues.
+func WithExpressQueue(queue *PlacementRequestQueue) QueueIteratorOption {
+	return func(q *QueueIterator) {
+		q.express = queue
+	}
+}
@@ -19,6 +19,7 @@ package queue
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"kombiner/pkg/apis/kombiner/v1alpha1"
 )
@@ -30,6 +31,8 @@ type QueueIterator struct {
 	readerFactory ReaderFactory
 	configs       QueueConfigs
 	resume        chan bool
+	state         *FairnessState
+	express       *PlacementRequestQueue
 }
 
 // Resume ensures we have a resume signal ready to be intercepted by the Run()
@@ -49,7 +52,12 @@ func (q *QueueIterator) Resume() {
 // queue does not deliver us a message we exclude it from the list of repeat
 // the process. We keep doing this until we either find a message in one of the
 // queues or we found all queues to be empty. In the latter case we then wait
-// for a resume signal to be sent by the PushHandler of one of the queues.
+// for a resume signal to be sent by the PushHandler of one of the queues. If
+// the iterator has a FairnessState every PlacementRequest sent is recorded in
+// it and readers implementing StatefulReader get access to it. If there is
+// an express queue it is always read first, as long as its rate limit
+// allows, bypassing the reader altogether. If we run out of things to read
+// because the queues are throttled we wake up once they can be read again.
 func (q *QueueIterator) Run(ctx context.Context) {
 	defer close(q.Next)
 
@@ -64,20 +72,80 @@ func (q *QueueIterator) Run(ctx context.Context) {
 		configs = append(configs, q.configs...)
 
 		reader := q.readerFactory(configs)
-		for p := reader.Read(ctx); p != nil; p = reader.Read(ctx) {
+		if stateful, ok := reader.(StatefulReader); ok && q.state != nil {
+			stateful.SetFairnessState(q.state)
+		}
+
+		for p, express := q.read(ctx, reader); p != nil; p, express = q.read(ctx, reader) {
 			select {
 			case <-ctx.Done():
 			case q.Next <- p:
+				// express requests bypass fairness so we don't
+				// account for them.
+				if q.state != nil && !express {
+					q.state.Record(p.Spec.SchedulerName, len(p.Spec.Bindings))
+				}
 			}
 		}
 
 		// nothing found to read in the queues, we now need to wait for
-		// the resume signal so we can resume reading.
+		// the resume signal so we can resume reading. if any queue is
+		// only waiting on its rate limit we wake up once it allows us
+		// to read again.
+		var timer *time.Timer
+		var retry <-chan time.Time
+		if delay, ok := q.delay(); ok {
+			timer = time.NewTimer(delay)
+			retry = timer.C
+		}
+
 		select {
 		case <-ctx.Done():
 		case <-q.resume:
+		case <-retry:
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// read returns the next PlacementRequest, from the express queue if there is
+// one available within its rate limit or from the reader otherwise. Returns
+// true if the PlacementRequest came from the express queue.
+func (q *QueueIterator) read(ctx context.Context, reader Reader) (*v1alpha1.PlacementRequest, bool) {
+	if q.express != nil {
+		if pr := q.express.Pop(); pr != nil {
+			return pr, true
 		}
 	}
+	return reader.Read(ctx), false
+}
+
+// delay returns how long we need to wait until one of the throttled queues
+// can be read again. Returns false if no queue is being throttled.
+func (q *QueueIterator) delay() (time.Duration, bool) {
+	queues := []*PlacementRequestQueue{}
+	for _, cfg := range q.configs {
+		queues = append(queues, cfg.QueueRef)
+	}
+	if q.express != nil {
+		queues = append(queues, q.express)
+	}
+
+	var shortest time.Duration
+	found := false
+	for _, queue := range queues {
+		delay, ok := queue.Delay()
+		if !ok {
+			continue
+		}
+		if !found || delay < shortest {
+			shortest, found = delay, true
+		}
+	}
+	return shortest, found
 }
 
 // NewQueueIterator creates a queue iterator based on the provided QueueConfig
@@ -101,5 +169,9 @@ func NewQueueIterator(configs QueueConfigs, opts ...QueueIteratorOption) (*Queue
 		opt(it)
 	}
 
+	if it.express != nil {
+		it.express.AddPushHandler(it.Resume)
+	}
+
 	return it, nil
 }
@@ -25,6 +25,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	configv1alpha1 "kombiner/pkg/apis/config/v1alpha1"
 	"kombiner/pkg/apis/kombiner/v1alpha1"
@@ -116,9 +118,17 @@ func TestQueueIteratorFairness(t *testing.T) {
 	for i := range len(configs) {
 		go func(idx int) {
 			name := fmt.Sprintf("scheduler-%d", idx%len(configs)+1)
-			for range 1000000 {
+			for j := range 1000000 {
+				// keep the queues filled but bounded, millions of
+				// indexed requests would take too much memory.
+				for configs[idx].QueueRef.Len() > 10000 {
+					time.Sleep(time.Millisecond)
+				}
 				configs[idx].QueueRef.Push(
 					&v1alpha1.PlacementRequest{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: fmt.Sprintf("%s-%d", name, j),
+						},
 						Spec: v1alpha1.PlacementRequestSpec{
 							SchedulerName: name,
 						},
@@ -250,11 +260,14 @@ func TestQueueIteratorMultipleConcurrent(t *testing.T) {
 	for i := range 100 {
 		go func(idx int) {
 			config := configs[idx%len(configs)]
-			for range 1000 {
+			for j := range 1000 {
 				sleep := rand.Intn(10)
 				time.Sleep(time.Duration(sleep) * time.Millisecond)
 				config.QueueRef.Push(
 					&v1alpha1.PlacementRequest{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: fmt.Sprintf("pr-%d-%d", idx, j),
+						},
 						Spec: v1alpha1.PlacementRequestSpec{
 							SchedulerName: config.SchedulerName,
 						},
@@ -317,10 +330,16 @@ func TestQueueContextCancellation(t *testing.T) {
 
 	for i := range len(configs) {
 		go func(idx int) {
-			for {
+			for j := 0; ; j++ {
 				sleep := time.Duration(rand.Intn(100))
 				time.Sleep(sleep * time.Millisecond)
-				configs[i].QueueRef.Push(&v1alpha1.PlacementRequest{})
+				configs[i].QueueRef.Push(
+					&v1alpha1.PlacementRequest{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: fmt.Sprintf("pr-%d-%d", idx, j),
+						},
+					},
+				)
 			}
 		}(i)
 	}
@@ -347,3 +366,77 @@ func TestQueueContextCancellation(t *testing.T) {
 		t.Fatalf("timeout waiting for iterator to finish")
 	}
 }
+
+func TestQueueIteratorExpressQueue(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 1, MaxSize: 10},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+	for i := range 10 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 1))
+	}
+
+	// the limiter allows two bindings and barely refills so only the
+	// first two express requests go ahead of the regular ones.
+	limiter := rate.NewLimiter(rate.Limit(0.001), 2)
+	express := NewPlacementRequestQueue(WithRateLimit(limiter))
+	for i := range 5 {
+		express.Push(sizedPlacementRequest("express", i, 1))
+	}
+
+	iterator, err := NewQueueIterator(
+		configs,
+		WithReaderFactory(NewRoundRobinReader),
+		WithExpressQueue(express),
+	)
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go iterator.Run(ctx)
+
+	var got []string
+	for range 4 {
+		got = append(got, (<-iterator.Next).Spec.SchedulerName)
+	}
+	require.Equal([]string{"express", "express", "a", "a"}, got)
+}
+
+func TestQueueIteratorExpressQueueRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 1, MaxSize: 10},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	// with nothing else to read the iterator must wake up by itself
+	// once the limiter allows the next express request through.
+	limiter := rate.NewLimiter(rate.Limit(20), 1)
+	express := NewPlacementRequestQueue(WithRateLimit(limiter))
+	for i := range 3 {
+		express.Push(sizedPlacementRequest("express", i, 1))
+	}
+
+	iterator, err := NewQueueIterator(configs, WithExpressQueue(express))
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	go iterator.Run(ctx)
+
+	for range 3 {
+		select {
+		case pr := <-iterator.Next:
+			require.Equal("express", pr.Spec.SchedulerName)
+		case <-ctx.Done():
+			require.Fail("timed out waiting for express requests")
+		}
+	}
+}
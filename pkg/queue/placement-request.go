@@ -17,79 +17,307 @@ limitations under the License.
 package queue
 
 import (
-	"container/heap"
+	"cmp"
+	"math"
+	"slices"
 	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
 
 	"kombiner/pkg/apis/kombiner/v1alpha1"
 )
 
 // PrioritizedPlacementRequest wraps a PlacementRequest and provides a function
 // to return its priority. We need this because the PriorityQueue operates on
-// objects that implement the Prioritized interface.
+// objects that implement the Prioritized interface. The key, sequence, time
+// of arrival and score are assigned by the queue when the PlacementRequest is
+// pushed.
 type PrioritizedPlacementRequest struct {
 	*v1alpha1.PlacementRequest
+	key      string
+	sequence uint64
+	queuedAt time.Time
+	score    float64
+	front    bool
 }
 
 // Priority returns the priority of the PlacementRequest. This is used by the
-// PriorityQueue to determine the order of items in the queue. We are using
-// the CreationTimestamp of the PlacementRequest as the priority, which means
-// that older requests have higher priority. The priority is expressed as an
-// int64 value representing the Unix timestamp in nanoseconds.
+// PriorityQueue to determine the order of items in the queue. Without aging
+// this is the Spec.Priority of the PlacementRequest, the higher the value the
+// sooner the PlacementRequest is served. With aging it is the priority the
+// PlacementRequest had when the queue was created, see Aging. PlacementRequests
+// pushed to the front of the queue have the highest possible priority.
 func (p *PrioritizedPlacementRequest) Priority() int64 {
-	return p.PlacementRequest.CreationTimestamp.UnixNano()
+	if p.front {
+		return math.MaxInt64
+	}
+	return int64(math.Floor(p.score))
+}
+
+// Before breaks ties between PlacementRequests with the same priority. With
+// aging the score fraction is taken into account first. Older requests, by
+// CreationTimestamp, are served first. As the timestamp has a second
+// granularity we fallback to the order in which the requests were pushed
+// into the queue.
+func (p *PrioritizedPlacementRequest) Before(other Prioritized) bool {
+	o, ok := other.(*PrioritizedPlacementRequest)
+	if !ok {
+		panic("cannot compare PlacementRequest with a different type")
+	}
+
+	if p.score != o.score {
+		return p.score > o.score
+	}
+
+	created := p.PlacementRequest.CreationTimestamp.Time
+	ocreated := o.PlacementRequest.CreationTimestamp.Time
+	if !created.Equal(ocreated) {
+		return created.Before(ocreated)
+	}
+	return p.sequence < o.sequence
+}
+
+// Key returns the namespace and name of the PlacementRequest. This uniquely
+// identifies the PlacementRequest in a queue.
+func (p *PrioritizedPlacementRequest) Key() string {
+	return p.key
+}
+
+// PlacementRequestKey returns the key used to identify a PlacementRequest in
+// the queue. The key has the namespace/name format.
+func PlacementRequestKey(pr *v1alpha1.PlacementRequest) string {
+	return pr.Namespace + "/" + pr.Name
 }
 
 // This global variable ensure that PrioritizedPlacementRequest implements the
 // Prioritized interface.
 var _ Prioritized = &PrioritizedPlacementRequest{}
 
+// QueuedPlacementRequest describes a PlacementRequest waiting in a queue. It
+// is meant for debugging purposes.
+type QueuedPlacementRequest struct {
+	Key               string    `json:"key"`
+	Tenant            string    `json:"tenant,omitempty"`
+	Priority          int32     `json:"priority"`
+	EffectivePriority int64     `json:"effectivePriority"`
+	QueuedAt          time.Time `json:"queuedAt"`
+}
+
 // PlacementRequestQueue is a prioritized queue for PlacementRequest objects.
 // Uses may call Push to add a PlacementRequest to the queue and Pop to remove
-// the highest priority PlacementRequest from the queue.
+// the highest priority PlacementRequest from the queue. If a Tenancy is set
+// the queue is split into one sub-queue per tenant, Pop then picks a tenant
+// first and the highest priority PlacementRequest of that tenant second. If
+// an Aging is set the priority of the PlacementRequests rises as they wait.
+// If a rate limit is set nothing can be read from the queue while it is
+// being throttled.
 type PlacementRequestQueue struct {
-	mtx          sync.Mutex
-	queue        *PriorityQueue
-	pushHandlers []func()
+	mtx              sync.Mutex
+	tenancy          *Tenancy
+	aging            *Aging
+	limiter          *rate.Limiter
+	throttled        bool
+	tenants          map[string]*tenantQueue
+	tenantOf         map[string]string
+	pushHandlers     []func()
+	throttleHandlers []func()
+	sequence         uint64
+	epoch            time.Time
+	now              func() time.Time
 }
 
 // Push adds a PlacementRequest to the queue. The PlacementRequest is wrapped
 // in a PrioritizedPlacementRequest to provide the necessary priority func.
+// If a PlacementRequest with the same key is already queued it is replaced,
+// keeping its original place in the queue if its priority hasn't changed.
 // Push handlers are called after the PlacementRequest is added to the queue.
 // It is the role of the caller to ensure that the PlacementRequest points to
 // a valid object and not directly to nil, the latter will cause this to
 // panic immediately.
 func (q *PlacementRequestQueue) Push(pr *v1alpha1.PlacementRequest) {
+	q.push(pr, false)
+}
+
+// Replace replaces the queued PlacementRequest with the same key, keeping its
+// place in the queue. Returns false, and does nothing, if no PlacementRequest
+// with the same key is queued.
+func (q *PlacementRequestQueue) Replace(pr *v1alpha1.PlacementRequest) bool {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
 
+	if _, found := q.tenantOf[PlacementRequestKey(pr)]; !found {
+		return false
+	}
+	q.insert(pr, false)
+	return true
+}
+
+// PushFront adds a PlacementRequest ahead of every other PlacementRequest in
+// its (tenant) queue, regardless of their priorities. This is meant for
+// PlacementRequests that were popped but could not be processed and must
+// not lose their turn.
+func (q *PlacementRequestQueue) PushFront(pr *v1alpha1.PlacementRequest) {
+	q.push(pr, true)
+}
+
+// push adds the PlacementRequest to the queue, see Push and PushFront.
+func (q *PlacementRequestQueue) push(pr *v1alpha1.PlacementRequest, front bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.insert(pr, front)
+}
+
+// insert adds the PlacementRequest to the queue, replacing the one with the
+// same key if any. A PlacementRequest replacing one that was pushed to the
+// front stays at the front. Callers must hold the lock.
+func (q *PlacementRequestQueue) insert(pr *v1alpha1.PlacementRequest, front bool) {
 	if pr == nil {
 		// this should never happen and if it does we should stop.
 		panic("cannot push a nil PlacementRequest to the queue")
 	}
 
-	wrapped := &PrioritizedPlacementRequest{PlacementRequest: pr}
-	heap.Push(q.queue, wrapped)
+	wrapped := &PrioritizedPlacementRequest{
+		PlacementRequest: pr,
+		key:              PlacementRequestKey(pr),
+		queuedAt:         queuedAt(pr),
+		front:            front,
+	}
+
+	// the tenant may have changed (e.g. the tenant label was updated) in
+	// which case we move the PlacementRequest to its new tenant queue.
+	name := q.tenancy.tenant(pr)
+	var current *PrioritizedPlacementRequest
+	if tenant, found := q.tenantOf[wrapped.key]; found {
+		current = q.tenants[tenant].remove(wrapped.key)
+		if tenant != name {
+			q.drop(q.tenants[tenant])
+		}
+	}
+
+	if current != nil {
+		wrapped.front = wrapped.front || current.front
+		wrapped.sequence = current.sequence
+		if wrapped.queuedAt.IsZero() {
+			wrapped.queuedAt = current.queuedAt
+		}
+	} else {
+		q.sequence++
+		wrapped.sequence = q.sequence
+	}
+
+	if wrapped.queuedAt.IsZero() {
+		wrapped.queuedAt = q.now()
+	}
+	wrapped.score = q.aging.score(pr, wrapped.queuedAt, q.epoch)
+
+	q.tenant(name).push(wrapped)
+	q.tenantOf[wrapped.key] = name
+
 	for _, handler := range q.pushHandlers {
 		handler()
 	}
 }
 
 // Pop removes and returns the highest priority PlacementRequest from the
-// queue. If the queue is empty this function will return nil.
+// queue. If the queue is empty, or throttled, this function will return nil.
 func (q *PlacementRequestQueue) Pop() *v1alpha1.PlacementRequest {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
 
-	if q.queue.Len() == 0 {
+	tenant, head := q.next()
+	if head == nil || !q.allowed(head.PlacementRequest) {
+		return nil
+	}
+
+	q.take(head.PlacementRequest)
+	return q.pop(tenant, head)
+}
+
+// Peek returns the highest priority PlacementRequest without removing it from
+// the queue. If the queue is empty, or throttled, this function will return
+// nil.
+func (q *PlacementRequestQueue) Peek() *v1alpha1.PlacementRequest {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	_, head := q.next()
+	if head == nil || !q.allowed(head.PlacementRequest) {
+		return nil
+	}
+	return head.PlacementRequest
+}
+
+// PopIf removes and returns the highest priority PlacementRequest from the
+// queue only if the provided function accepts it. Returns nil if the queue
+// is empty, throttled or the PlacementRequest was not accepted.
+func (q *PlacementRequestQueue) PopIf(accept func(*v1alpha1.PlacementRequest) bool) *v1alpha1.PlacementRequest {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	tenant, head := q.next()
+	if head == nil || !q.allowed(head.PlacementRequest) || !accept(head.PlacementRequest) {
+		return nil
+	}
+
+	q.take(head.PlacementRequest)
+	return q.pop(tenant, head)
+}
+
+// PopExpired removes and returns the PlacementRequests that have been waiting
+// for longer than the maximum wait. This only returns something if the queue
+// has been configured to reject these PlacementRequests, it is up to the
+// caller to do so.
+func (q *PlacementRequestQueue) PopExpired() []*v1alpha1.PlacementRequest {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if !q.aging.rejectExpired() {
 		return nil
 	}
 
-	result, ok := heap.Pop(q.queue).(*PrioritizedPlacementRequest)
-	if !ok || result.PlacementRequest == nil {
-		panic("PlacementRequest queue found an unexpected object")
+	var expired []*v1alpha1.PlacementRequest
+	for {
+		tenant, oldest := q.expired()
+		if oldest == nil {
+			return expired
+		}
+		expired = append(expired, q.pop(tenant, oldest))
+	}
+}
+
+// Remove removes the PlacementRequest with the provided key from the queue.
+// Returns true if the PlacementRequest was found and removed.
+func (q *PlacementRequestQueue) Remove(key string) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	name, found := q.tenantOf[key]
+	if !found {
+		return false
 	}
 
-	return result.PlacementRequest
+	tenant := q.tenants[name]
+	tenant.remove(key)
+	delete(q.tenantOf, key)
+	q.drop(tenant)
+	return true
+}
+
+// Clear removes all PlacementRequests from the queue.
+func (q *PlacementRequestQueue) Clear() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.tenants = map[string]*tenantQueue{}
+	q.tenantOf = map[string]string{}
+}
+
+// Has returns true if a PlacementRequest with the provided key is queued.
+func (q *PlacementRequestQueue) Has(key string) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	_, found := q.tenantOf[key]
+	return found
 }
 
 // AddPushHandler adds a handler that is called every time a PlacementRequest
@@ -104,13 +332,153 @@ func (q *PlacementRequestQueue) AddPushHandler(handler func()) {
 func (q *PlacementRequestQueue) Len() int {
 	q.mtx.Lock()
 	defer q.mtx.Unlock()
-	return q.queue.Len()
+	return len(q.tenantOf)
+}
+
+// Dump returns the PlacementRequests currently queued sorted by their
+// effective priority. This is meant for debugging purposes.
+func (q *PlacementRequestQueue) Dump() []QueuedPlacementRequest {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	now := q.now()
+	var result []QueuedPlacementRequest
+	for name, tenant := range q.tenants {
+		for i := range tenant.queue.Len() {
+			item := tenant.queue.Get(i).(*PrioritizedPlacementRequest)
+			result = append(result, QueuedPlacementRequest{
+				Key:               item.key,
+				Tenant:            name,
+				Priority:          int32(item.Spec.Priority),
+				EffectivePriority: q.aging.effective(item.score, now, q.epoch),
+				QueuedAt:          item.queuedAt,
+			})
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b QueuedPlacementRequest) int {
+		if c := cmp.Compare(b.EffectivePriority, a.EffectivePriority); c != 0 {
+			return c
+		}
+		return a.QueuedAt.Compare(b.QueuedAt)
+	})
+	return result
+}
+
+// next returns the PlacementRequest we should be serving next and the tenant
+// queue it belongs to. PlacementRequests that waited for too long go first,
+// if so configured. Otherwise we pick the tenant queue with the lowest
+// virtual time, ties are broken by the PlacementRequests at the head of each
+// tenant queue. Returns nil if there is nothing queued. Callers must hold
+// the lock.
+func (q *PlacementRequestQueue) next() (*tenantQueue, *PrioritizedPlacementRequest) {
+	if q.aging.serveExpired() {
+		if tenant, oldest := q.expired(); oldest != nil {
+			return tenant, oldest
+		}
+	}
+
+	var next *tenantQueue
+	for _, tenant := range q.tenants {
+		if next == nil || tenant.vtime < next.vtime {
+			next = tenant
+			continue
+		}
+		if tenant.vtime == next.vtime && tenant.ahead(next) {
+			next = tenant
+		}
+	}
+
+	if next == nil {
+		return nil, nil
+	}
+	return next, next.head()
+}
+
+// expired returns the PlacementRequest that has been waiting for the longest
+// if it has been waiting for more than the maximum wait. Callers must hold
+// the lock.
+func (q *PlacementRequestQueue) expired() (*tenantQueue, *PrioritizedPlacementRequest) {
+	if q.aging == nil || q.aging.MaxWait <= 0 {
+		return nil, nil
+	}
+
+	var next *tenantQueue
+	var oldest *PrioritizedPlacementRequest
+	for _, tenant := range q.tenants {
+		candidate := tenant.oldest()
+		if oldest == nil || candidate.queuedAt.Before(oldest.queuedAt) {
+			next, oldest = tenant, candidate
+		}
+	}
+
+	if oldest == nil || q.now().Sub(oldest.queuedAt) < q.aging.MaxWait {
+		return nil, nil
+	}
+	return next, oldest
+}
+
+// pop removes the provided PlacementRequest from the tenant queue and charges
+// the tenant for it. Callers must hold the lock.
+func (q *PlacementRequestQueue) pop(tenant *tenantQueue, item *PrioritizedPlacementRequest) *v1alpha1.PlacementRequest {
+	tenant.remove(item.key)
+	delete(q.tenantOf, item.key)
+	tenant.charge(item.PlacementRequest)
+	q.drop(tenant)
+	return item.PlacementRequest
+}
+
+// drop removes the provided tenant queue if it is empty. Callers must hold
+// the lock.
+func (q *PlacementRequestQueue) drop(tenant *tenantQueue) {
+	if tenant.queue.Len() == 0 {
+		delete(q.tenants, tenant.name)
+	}
+}
+
+// tenant returns the queue for the provided tenant, creating it if needed. A
+// new tenant queue starts at the lowest virtual time among the busy ones so
+// it can't claim the time it spent idle. Callers must hold the lock.
+func (q *PlacementRequestQueue) tenant(name string) *tenantQueue {
+	if tenant, ok := q.tenants[name]; ok {
+		return tenant
+	}
+
+	var vtime float64
+	first := true
+	for _, tenant := range q.tenants {
+		if first || tenant.vtime < vtime {
+			vtime = tenant.vtime
+			first = false
+		}
+	}
+
+	tenant := newTenantQueue(name, q.tenancy.weight(name))
+	tenant.vtime = vtime
+	q.tenants[name] = tenant
+	return tenant
+}
+
+// queuedAt returns the time the PlacementRequest was queued. Falls back to
+// its creation time and returns a zero time if none are known.
+func queuedAt(pr *v1alpha1.PlacementRequest) time.Time {
+	if pr.Status.QueuedAt != nil {
+		return pr.Status.QueuedAt.Time
+	}
+	return pr.CreationTimestamp.Time
 }
 
 // NewPlacementRequestQueue creates a new PlacementRequestQueue.
-func NewPlacementRequestQueue() *PlacementRequestQueue {
-	return &PlacementRequestQueue{
-		queue:        newPriorityQueue(),
+func NewPlacementRequestQueue(opts ...PlacementRequestQueueOption) *PlacementRequestQueue {
+	q := &PlacementRequestQueue{
+		tenants:      map[string]*tenantQueue{},
+		tenantOf:     map[string]string{},
 		pushHandlers: []func(){},
+		now:          time.Now,
+	}
+	for _, opt := range opts {
+		opt(q)
 	}
+	q.epoch = q.now()
+	return q
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package queue
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -34,6 +35,7 @@ func TestPlacementRequestQueue(t *testing.T) {
 		sub := time.Duration(i) * time.Hour * -1
 		pr := &v1alpha1.PlacementRequest{
 			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("pr-%d", i),
 				CreationTimestamp: metav1.Time{
 					Time: metav1.Now().Time.Add(sub),
 				},
@@ -58,6 +60,34 @@ func TestPlacementRequestQueue(t *testing.T) {
 	}
 }
 
+func TestPlacementRequestQueueSpecPriority(t *testing.T) {
+	assert := assert.New(t)
+
+	now := metav1.Now()
+	queue := NewPlacementRequestQueue()
+	for i, priority := range []int{0, 10, 0, 5, 10} {
+		queue.Push(
+			&v1alpha1.PlacementRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              fmt.Sprintf("pr-%d", i),
+					CreationTimestamp: now,
+				},
+				Spec: v1alpha1.PlacementRequestSpec{
+					Priority: v1alpha1.PlacementRequestPriority(priority),
+				},
+			},
+		)
+	}
+
+	var names []string
+	for pr := queue.Pop(); pr != nil; pr = queue.Pop() {
+		names = append(names, pr.Name)
+	}
+
+	expected := []string{"pr-1", "pr-4", "pr-3", "pr-0", "pr-2"}
+	assert.Equal(expected, names, "unexpected placement request order")
+}
+
 func TestPlacementRequestPushHandlers(t *testing.T) {
 	assert := assert.New(t)
 
@@ -68,9 +98,157 @@ func TestPlacementRequestPushHandlers(t *testing.T) {
 
 	queue := NewPlacementRequestQueue()
 	queue.AddPushHandler(pushHandler)
-	for range 10 {
-		queue.Push(&v1alpha1.PlacementRequest{})
+	for i := range 10 {
+		queue.Push(
+			&v1alpha1.PlacementRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pr-%d", i)},
+			},
+		)
 	}
 
 	assert.Equal(10, counter, "expected push handler to be called 10 times")
 }
+
+func TestPlacementRequestQueueRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := NewPlacementRequestQueue()
+	for i := range 10 {
+		queue.Push(
+			&v1alpha1.PlacementRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      fmt.Sprintf("pr-%d", i),
+				},
+			},
+		)
+	}
+
+	assert.True(queue.Remove("ns/pr-3"), "expected placement request to be removed")
+	assert.True(queue.Remove("ns/pr-7"), "expected placement request to be removed")
+	assert.False(queue.Remove("ns/pr-7"), "expected placement request to be gone")
+	assert.False(queue.Has("ns/pr-3"), "expected placement request to be gone")
+	assert.True(queue.Has("ns/pr-4"), "expected placement request to be queued")
+	assert.Equal(8, queue.Len(), "unexpected queue length")
+
+	var names []string
+	for pr := queue.Pop(); pr != nil; pr = queue.Pop() {
+		names = append(names, pr.Name)
+	}
+
+	expected := []string{"pr-0", "pr-1", "pr-2", "pr-4", "pr-5", "pr-6", "pr-8", "pr-9"}
+	assert.Equal(expected, names, "unexpected placement request order")
+}
+
+func TestPlacementRequestQueueReplace(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := NewPlacementRequestQueue()
+	for i := range 3 {
+		queue.Push(
+			&v1alpha1.PlacementRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      fmt.Sprintf("pr-%d", i),
+				},
+			},
+		)
+	}
+
+	// pushing the same placement request again replaces it and keeps
+	// its place in the queue.
+	queue.Push(
+		&v1alpha1.PlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pr-1"},
+			Spec:       v1alpha1.PlacementRequestSpec{SchedulerName: "updated"},
+		},
+	)
+	assert.Equal(3, queue.Len(), "expected no duplicates in the queue")
+
+	// a priority change moves the placement request to the front.
+	queue.Push(
+		&v1alpha1.PlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pr-2"},
+			Spec:       v1alpha1.PlacementRequestSpec{Priority: 10},
+		},
+	)
+	assert.Equal(3, queue.Len(), "expected no duplicates in the queue")
+
+	pr := queue.Pop()
+	assert.Equal("pr-2", pr.Name)
+	pr = queue.Pop()
+	assert.Equal("pr-0", pr.Name)
+	pr = queue.Pop()
+	assert.Equal("pr-1", pr.Name)
+	assert.Equal("updated", pr.Spec.SchedulerName, "expected the updated object")
+}
+
+func TestPlacementRequestQueuePushFront(t *testing.T) {
+	assert := assert.New(t)
+
+	queue := NewPlacementRequestQueue()
+	for i := range 3 {
+		queue.Push(
+			&v1alpha1.PlacementRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns",
+					Name:      fmt.Sprintf("pr-%d", i),
+				},
+				Spec: v1alpha1.PlacementRequestSpec{Priority: 10},
+			},
+		)
+	}
+
+	// a placement request pushed to the front goes first even if its
+	// priority is lower than the rest.
+	pr := queue.Pop()
+	assert.Equal("pr-0", pr.Name)
+	queue.PushFront(
+		&v1alpha1.PlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "requeued"},
+		},
+	)
+	assert.Equal(3, queue.Len())
+
+	// replacing it keeps it at the front, replacing something that is
+	// not queued does nothing.
+	assert.True(queue.Replace(
+		&v1alpha1.PlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "requeued"},
+			Spec:       v1alpha1.PlacementRequestSpec{SchedulerName: "updated"},
+		},
+	))
+	assert.False(queue.Replace(
+		&v1alpha1.PlacementRequest{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "pr-0"},
+		},
+	))
+	assert.Equal(3, queue.Len())
+
+	pr = queue.Pop()
+	assert.Equal("requeued", pr.Name)
+	assert.Equal("updated", pr.Spec.SchedulerName, "expected the replaced object")
+	pr = queue.Pop()
+	assert.Equal("pr-1", pr.Name)
+}
+
+func TestPlacementRequestQueuePopIf(t *testing.T) {
+	assert := assert.New(t)
+
+	q := NewPlacementRequestQueue()
+	assert.Nil(q.Peek())
+	assert.Nil(q.PopIf(func(*v1alpha1.PlacementRequest) bool { return true }))
+
+	q.Push(&v1alpha1.PlacementRequest{ObjectMeta: metav1.ObjectMeta{Name: "first"}})
+	q.Push(&v1alpha1.PlacementRequest{ObjectMeta: metav1.ObjectMeta{Name: "second"}})
+
+	assert.Nil(q.PopIf(func(*v1alpha1.PlacementRequest) bool { return false }))
+	assert.Equal(2, q.Len())
+	assert.Equal("first", q.Peek().Name)
+	assert.Equal(2, q.Len())
+
+	pr := q.PopIf(func(pr *v1alpha1.PlacementRequest) bool { return pr.Name == "first" })
+	assert.NotNil(pr)
+	assert.Equal("first", pr.Name)
+	assert.Equal(1, q.Len())
+}
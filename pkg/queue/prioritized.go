@@ -20,9 +20,22 @@ import (
 	"container/heap"
 )
 
-// Prioritized is an item that has a priority, expressed as an integer.
+// Prioritized is an item that has a priority, expressed as an integer. Items
+// with the same priority are ordered among themselves through Before.
 type Prioritized interface {
+	// Priority returns the item priority. The higher the number the
+	// higher the priority.
 	Priority() int64
+
+	// Before returns true if the item should be served before the
+	// provided one. This is only used to break ties between items
+	// with the same priority.
+	Before(Prioritized) bool
+
+	// Key returns a string that uniquely identifies the item in the
+	// queue. It is used to locate items that need to be removed or
+	// replaced.
+	Key() string
 }
 
 // This global variable is used to ensure that the PriorityQueue implements the
@@ -34,9 +47,33 @@ var _ heap.Interface = &PriorityQueue{}
 // interface. Attempting to push or pop a different type will result
 // in a panic. This struct isn't thread-safe, any blocking should be
 // done upstream. Functions here are not meant to be called directly
-// but rather through the heap go package.
+// but rather through the heap go package. The queue keeps an index of
+// the position of each item by its key so items can be located without
+// going through the whole heap.
 type PriorityQueue struct {
 	items []Prioritized
+	index map[string]int
+}
+
+// Index returns the position of the item with the provided key in the queue.
+// The position can be used with heap.Remove and heap.Fix. The returned bool
+// indicates if the item was found.
+func (q *PriorityQueue) Index(key string) (int, bool) {
+	idx, ok := q.index[key]
+	return idx, ok
+}
+
+// Get returns the item at the provided position.
+func (q *PriorityQueue) Get(idx int) Prioritized {
+	return q.items[idx]
+}
+
+// Set replaces the item at the provided position. The caller must make sure
+// heap.Fix is called afterwards as the item priority may have changed.
+func (q *PriorityQueue) Set(idx int, item Prioritized) {
+	delete(q.index, q.items[idx].Key())
+	q.items[idx] = item
+	q.index[item.Key()] = idx
 }
 
 // Len return the number of items in the queue.
@@ -45,14 +82,21 @@ func (q *PriorityQueue) Len() int {
 }
 
 // Less function is used to compare two prioritized objects based on their
-// priority. The lower the number the higher the priority.
+// priority. The higher the number the higher the priority, ties are broken
+// by the items themselves.
 func (q *PriorityQueue) Less(i, j int) bool {
-	return q.items[i].Priority() < q.items[j].Priority()
+	pi, pj := q.items[i].Priority(), q.items[j].Priority()
+	if pi != pj {
+		return pi > pj
+	}
+	return q.items[i].Before(q.items[j])
 }
 
 // Swap is used by the heap implementation to swap two elements in the queue.
 func (q *PriorityQueue) Swap(i, j int) {
 	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.index[q.items[i].Key()] = i
+	q.index[q.items[j].Key()] = j
 }
 
 // Push adds a new prioritized object onto the queue.
@@ -61,6 +105,7 @@ func (q *PriorityQueue) Push(pr any) {
 	if !ok {
 		panic("queue only accepts prioritized items")
 	}
+	q.index[prioritized.Key()] = len(q.items)
 	q.items = append(q.items, prioritized)
 }
 
@@ -70,6 +115,7 @@ func (q *PriorityQueue) Pop() any {
 	before, num := q.items, len(q.items)
 	pr := before[num-1]
 	q.items = before[0 : num-1]
+	delete(q.index, pr.Key())
 	return pr
 }
 
@@ -77,7 +123,7 @@ func (q *PriorityQueue) Pop() any {
 // it. The queue is initialized as an empty heap. We do not want to make it
 // public as it is not meant to be used directly.
 func newPriorityQueue() *PriorityQueue {
-	q := &PriorityQueue{}
+	q := &PriorityQueue{index: map[string]int{}}
 	heap.Init(q)
 	return q
 }
@@ -0,0 +1,125 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// WithRateLimit caps the amount of bindings read out of the queue. Each
+// binding read takes a token from the provided limiter, while there aren't
+// enough tokens for the PlacementRequest at the head of the queue the queue
+// behaves as if it was empty for readers. A nil limiter means no limit.
+func WithRateLimit(limiter *rate.Limiter) PlacementRequestQueueOption {
+	return func(q *PlacementRequestQueue) {
+		q.limiter = limiter
+	}
+}
+
+// NewRateLimiterFromV1Alpha1 returns the limiter for a queue as configured in
+// the controller configuration. Returns nil if the queue has no limit. The
+// burst defaults to the amount of bindings per second.
+func NewRateLimiterFromV1Alpha1(cfg configapi.Queue) *rate.Limiter {
+	if cfg.BindingsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := cfg.Burst
+	if burst <= 0 {
+		burst = cfg.BindingsPerSecond
+	}
+	return rate.NewLimiter(rate.Limit(cfg.BindingsPerSecond), int(burst))
+}
+
+// Ready returns true if there is a PlacementRequest that can be read out of
+// the queue right now, i.e. the queue is not empty and it is not being
+// throttled. Readers should rely on this instead of Len to decide if there
+// is anything to read from a queue.
+func (q *PlacementRequestQueue) Ready() bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	_, head := q.next()
+	return head != nil && q.allowed(head.PlacementRequest)
+}
+
+// Delay returns how long until the PlacementRequest at the head of the queue
+// can be read. Returns false if the queue is empty or has no rate limit.
+func (q *PlacementRequestQueue) Delay() (time.Duration, bool) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	_, head := q.next()
+	if head == nil || q.limiter == nil {
+		return 0, false
+	}
+
+	now := q.now()
+	reservation := q.limiter.ReserveN(now, tokens(head.PlacementRequest, q.limiter))
+	defer reservation.CancelAt(now)
+	return reservation.DelayFrom(now), true
+}
+
+// AddThrottleHandler adds a handler that is called every time the queue
+// starts being throttled by its rate limit.
+func (q *PlacementRequestQueue) AddThrottleHandler(handler func()) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	q.throttleHandlers = append(q.throttleHandlers, handler)
+}
+
+// allowed returns true if the rate limit allows the PlacementRequest to be
+// read, no tokens are taken. The throttle handlers are called when we go
+// from allowing to not allowing. Callers must hold the lock.
+func (q *PlacementRequestQueue) allowed(pr *v1alpha1.PlacementRequest) bool {
+	if q.limiter == nil {
+		return true
+	}
+
+	if q.limiter.TokensAt(q.now()) >= float64(tokens(pr, q.limiter)) {
+		return true
+	}
+
+	if !q.throttled {
+		q.throttled = true
+		for _, handler := range q.throttleHandlers {
+			handler()
+		}
+	}
+	return false
+}
+
+// take takes the tokens for the PlacementRequest from the rate limit. Callers
+// must hold the lock and make sure the PlacementRequest is allowed first.
+func (q *PlacementRequestQueue) take(pr *v1alpha1.PlacementRequest) {
+	q.throttled = false
+	if q.limiter != nil {
+		q.limiter.AllowN(q.now(), tokens(pr, q.limiter))
+	}
+}
+
+// tokens returns the amount of tokens a PlacementRequest takes from a rate
+// limit, one per binding. It never exceeds the limiter burst otherwise the
+// PlacementRequest would never be allowed through.
+func tokens(pr *v1alpha1.PlacementRequest, limiter *rate.Limiter) int {
+	return max(min(len(pr.Spec.Bindings), limiter.Burst()), 1)
+}
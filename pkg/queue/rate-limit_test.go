@@ -0,0 +1,159 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+)
+
+// limitedQueue returns a queue limited to the provided rate and burst whose
+// clock is controlled through the returned pointer.
+func limitedQueue(limit rate.Limit, burst int) (*PlacementRequestQueue, *time.Time) {
+	now := time.Now()
+	queue := NewPlacementRequestQueue(WithRateLimit(rate.NewLimiter(limit, burst)))
+	queue.now = func() time.Time { return now }
+	return queue, &now
+}
+
+func TestPlacementRequestQueueRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	queue, now := limitedQueue(1, 2)
+	throttled := 0
+	queue.AddThrottleHandler(func() { throttled++ })
+
+	for i := range 3 {
+		queue.Push(sizedPlacementRequest("a", i, 1))
+	}
+
+	// the burst lets the first two through, the third one has to wait
+	// for the bucket to refill.
+	require.True(queue.Ready())
+	require.NotNil(queue.Pop())
+	require.NotNil(queue.Pop())
+	require.False(queue.Ready())
+	require.Nil(queue.Peek())
+	require.Nil(queue.Pop())
+	require.Equal(1, queue.Len())
+
+	delay, ok := queue.Delay()
+	require.True(ok)
+	require.Equal(time.Second, delay)
+
+	// the handlers are only called when the throttling starts.
+	require.Equal(1, throttled)
+
+	*now = now.Add(time.Second)
+	require.True(queue.Ready())
+	require.NotNil(queue.Pop())
+	require.Equal(0, queue.Len())
+
+	_, ok = queue.Delay()
+	require.False(ok)
+}
+
+func TestPlacementRequestQueueRateLimitBindings(t *testing.T) {
+	require := require.New(t)
+
+	// a request takes one token per binding, capped at the burst so large
+	// requests still go through once the bucket is full.
+	queue, now := limitedQueue(1, 4)
+	queue.Push(sizedPlacementRequest("a", 0, 3))
+	queue.Push(sizedPlacementRequest("a", 1, 10))
+
+	require.NotNil(queue.Pop())
+	require.Nil(queue.Pop())
+
+	delay, ok := queue.Delay()
+	require.True(ok)
+	require.Equal(3*time.Second, delay)
+
+	*now = now.Add(3 * time.Second)
+	pr := queue.Pop()
+	require.NotNil(pr)
+	require.Len(pr.Spec.Bindings, 10)
+}
+
+func TestPlacementRequestQueueWithoutRateLimit(t *testing.T) {
+	require := require.New(t)
+
+	queue := NewPlacementRequestQueue(WithRateLimit(nil))
+	for i := range 100 {
+		queue.Push(sizedPlacementRequest("a", i, 10))
+	}
+	for range 100 {
+		require.NotNil(queue.Pop())
+	}
+
+	_, ok := queue.Delay()
+	require.False(ok)
+}
+
+func TestNewRateLimiterFromV1Alpha1(t *testing.T) {
+	require := require.New(t)
+
+	require.Nil(NewRateLimiterFromV1Alpha1(configapi.Queue{}))
+
+	limiter := NewRateLimiterFromV1Alpha1(configapi.Queue{BindingsPerSecond: 5})
+	require.Equal(rate.Limit(5), limiter.Limit())
+	require.Equal(5, limiter.Burst())
+
+	limiter = NewRateLimiterFromV1Alpha1(configapi.Queue{BindingsPerSecond: 5, Burst: 20})
+	require.Equal(20, limiter.Burst())
+}
+
+func TestReadersSkipThrottledQueues(t *testing.T) {
+	for _, name := range Registered() {
+		t.Run(string(name), func(t *testing.T) {
+			require := require.New(t)
+
+			// queue a can only serve one binding and is then
+			// throttled, the readers must keep serving queue b.
+			a, _ := limitedQueue(rate.Limit(0.001), 1)
+			b := NewPlacementRequestQueue()
+			for i := range 5 {
+				a.Push(sizedPlacementRequest("a", i, 1))
+				b.Push(sizedPlacementRequest("b", i, 1))
+			}
+
+			configs := QueueConfigs{
+				{Queue: configapi.Queue{SchedulerName: "a", Weight: 1}, QueueRef: a},
+				{Queue: configapi.Queue{SchedulerName: "b", Weight: 1}, QueueRef: b},
+			}
+
+			builder, ok := Lookup(name)
+			require.True(ok)
+			factory, err := builder(nil, Handle{Resources: &fakeLookup{}})
+			require.NoError(err)
+			reader := factory(configs)
+
+			served := map[string]int{}
+			for pr := reader.Read(context.Background()); pr != nil; pr = reader.Read(context.Background()) {
+				served[pr.Spec.SchedulerName]++
+			}
+			require.Equal(map[string]int{"a": 1, "b": 5}, served)
+			require.Equal(4, a.Len())
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+)
+
+// Handle gives the ReaderBuilders access to what lives outside of the queues,
+// e.g. the pods and nodes in the cluster.
+type Handle struct {
+	// Resources provides the cluster capacity and the resources requested
+	// by the pods of a placement request.
+	Resources ResourceLookup
+}
+
+// ReaderBuilder builds the ReaderFactory for a fairness algorithm out of its
+// arguments, as provided in the configuration. Args may be nil.
+type ReaderBuilder func(args *runtime.RawExtension, handle Handle) (ReaderFactory, error)
+
+// registry maps fairness algorithm names to their ReaderBuilders.
+var registry = struct {
+	mtx      sync.RWMutex
+	builders map[configapi.FairnessAlgorithm]ReaderBuilder
+}{
+	builders: map[configapi.FairnessAlgorithm]ReaderBuilder{},
+}
+
+func init() {
+	MustRegister(configapi.RoundRobin, withoutArgs(NewRoundRobinReader))
+	MustRegister(configapi.Uniform, withoutArgs(NewUniformReader))
+	MustRegister(configapi.DeficitRoundRobin, withoutArgs(NewDeficitRoundRobinReader))
+	MustRegister(
+		configapi.WeightedFairQueueing,
+		func(args *runtime.RawExtension, _ Handle) (ReaderFactory, error) {
+			if err := DecodeArgs(args, &struct{}{}); err != nil {
+				return nil, err
+			}
+			return NewWeightedFairQueueingReaderFactory(), nil
+		},
+	)
+	MustRegister(
+		configapi.DominantResource,
+		func(args *runtime.RawExtension, handle Handle) (ReaderFactory, error) {
+			if err := DecodeArgs(args, &struct{}{}); err != nil {
+				return nil, err
+			}
+			if handle.Resources == nil {
+				return nil, fmt.Errorf("no resource lookup provided")
+			}
+			return NewDominantResourceReaderFactory(handle.Resources), nil
+		},
+	)
+}
+
+// Register makes a fairness algorithm available under the provided name. It
+// is meant to be called from an init function by out-of-tree readers before
+// the configuration is loaded. Registering the same name twice is an error.
+func Register(name configapi.FairnessAlgorithm, builder ReaderBuilder) error {
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+
+	if name == "" {
+		return fmt.Errorf("fairness algorithm name cannot be empty")
+	}
+	if _, ok := registry.builders[name]; ok {
+		return fmt.Errorf("fairness algorithm %q already registered", name)
+	}
+	registry.builders[name] = builder
+	return nil
+}
+
+// MustRegister is like Register but panics on error.
+func MustRegister(name configapi.FairnessAlgorithm, builder ReaderBuilder) {
+	if err := Register(name, builder); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup returns the ReaderBuilder registered under the provided name.
+func Lookup(name configapi.FairnessAlgorithm) (ReaderBuilder, bool) {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+	builder, ok := registry.builders[name]
+	return builder, ok
+}
+
+// Registered returns the names of all registered fairness algorithms, sorted.
+func Registered() []configapi.FairnessAlgorithm {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+
+	names := make([]configapi.FairnessAlgorithm, 0, len(registry.builders))
+	for name := range registry.builders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// DecodeArgs decodes the arguments of a fairness algorithm into the provided
+// object. Unknown fields are rejected. Nil or empty args leave the object
+// untouched.
+func DecodeArgs(args *runtime.RawExtension, into any) error {
+	if args == nil || len(args.Raw) == 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(args.Raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(into); err != nil {
+		return fmt.Errorf("invalid fairness algorithm args: %w", err)
+	}
+	return nil
+}
+
+// withoutArgs returns a ReaderBuilder for algorithms that do not take any
+// arguments.
+func withoutArgs(factory ReaderFactory) ReaderBuilder {
+	return func(args *runtime.RawExtension, _ Handle) (ReaderFactory, error) {
+		if err := DecodeArgs(args, &struct{}{}); err != nil {
+			return nil, err
+		}
+		return factory, nil
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configv1alpha1 "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// firstQueueReader is a reader that always reads from the first queue. It
+// is used to test the registration of out-of-tree algorithms.
+type firstQueueReader struct {
+	configs QueueConfigs
+}
+
+func (r *firstQueueReader) Read(_ context.Context) *v1alpha1.PlacementRequest {
+	return r.configs[0].QueueRef.Pop()
+}
+
+func TestRegistryBuiltins(t *testing.T) {
+	require := require.New(t)
+
+	for _, name := range []configv1alpha1.FairnessAlgorithm{
+		configv1alpha1.RoundRobin,
+		configv1alpha1.Uniform,
+		configv1alpha1.DeficitRoundRobin,
+		configv1alpha1.WeightedFairQueueing,
+		configv1alpha1.DominantResource,
+	} {
+		builder, ok := Lookup(name)
+		require.True(ok, name)
+
+		factory, err := builder(nil, Handle{Resources: &fakeLookup{}})
+		require.NoError(err, name)
+		require.NotNil(factory, name)
+
+		_, err = builder(&runtime.RawExtension{Raw: []byte(`{"unknown":1}`)}, Handle{Resources: &fakeLookup{}})
+		require.Error(err, name)
+	}
+
+	require.Contains(Registered(), configv1alpha1.RoundRobin)
+}
+
+func TestRegistryOutOfTree(t *testing.T) {
+	require := require.New(t)
+
+	type args struct {
+		Queue int `json:"queue"`
+	}
+
+	var got args
+	builder := func(raw *runtime.RawExtension, _ Handle) (ReaderFactory, error) {
+		if err := DecodeArgs(raw, &got); err != nil {
+			return nil, err
+		}
+		return func(configs QueueConfigs) Reader {
+			return &firstQueueReader{configs: configs}
+		}, nil
+	}
+
+	require.NoError(Register("FirstQueue", builder))
+	require.Error(Register("FirstQueue", builder))
+	require.Error(Register(configv1alpha1.RoundRobin, builder))
+	require.Error(Register("", builder))
+
+	registered, ok := Lookup("FirstQueue")
+	require.True(ok)
+
+	factory, err := registered(&runtime.RawExtension{Raw: []byte(`{"queue":1}`)}, Handle{})
+	require.NoError(err)
+	require.Equal(args{Queue: 1}, got)
+
+	_, err = registered(&runtime.RawExtension{Raw: []byte(`{"other":1}`)}, Handle{})
+	require.Error(err)
+
+	configs := QueueConfigs{
+		{Queue: configv1alpha1.Queue{SchedulerName: "a", Weight: 1}, QueueRef: NewPlacementRequestQueue()},
+	}
+	configs[0].QueueRef.Push(sizedPlacementRequest("a", 0, 1))
+	pr := factory(configs).Read(context.Background())
+	require.NotNil(pr)
+	require.Equal("a-0", pr.Name)
+}
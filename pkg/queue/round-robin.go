@@ -37,8 +37,25 @@ type ExtendedQueueConfig struct {
 	BindingsRead    int
 }
 
+// RoundRobinReader reads from the queues in order, each one of them up to its
+// maximum number of bindings per round. If a FairnessState is provided the
+// budgets at the start of each round are adjusted so queues owed bandwidth
+// get extra bindings and queues that have been using more than their share
+// get less, by at most one round either way.
 type RoundRobinReader struct {
 	configs []ExtendedQueueConfig
+	state   *FairnessState
+}
+
+// This global variable is used to ensure that the RoundRobinReader implements
+// the StatefulReader interface.
+var _ StatefulReader = &RoundRobinReader{}
+
+// SetFairnessState sets the fairness history and adjusts the budgets for the
+// current round accordingly.
+func (r *RoundRobinReader) SetFairnessState(state *FairnessState) {
+	r.state = state
+	r.history()
 }
 
 // Read keeps reading from the same queue until it is empty or we reached the
@@ -77,10 +94,10 @@ func (r *RoundRobinReader) Read(ctx context.Context) *v1alpha1.PlacementRequest
 	return r.Read(ctx)
 }
 
-// empty returns true if all queues are empty.
+// empty returns true if all queues are empty or throttled.
 func (r *RoundRobinReader) empty() bool {
 	for _, cfg := range r.configs {
-		if cfg.QueueRef.Len() > 0 {
+		if cfg.QueueRef.Ready() {
 			return false
 		}
 	}
@@ -94,6 +111,29 @@ func (r *RoundRobinReader) reset() {
 		cfg.BindingsRead = 0
 		r.configs[i] = cfg
 	}
+	r.history()
+}
+
+// history adjusts the BindingsRead counters based on the fairness state. A
+// queue owed bindings starts with a negative counter, i.e. a larger budget,
+// and a queue that has been served too much starts with part of the budget
+// already spent. We always leave at least one binding of budget so there is
+// always a queue to read from.
+func (r *RoundRobinReader) history() {
+	if r.state == nil {
+		return
+	}
+
+	configs := make([]QueueConfig, len(r.configs))
+	for i, cfg := range r.configs {
+		configs[i] = cfg.QueueConfig
+	}
+
+	owed := r.state.Owed(configs)
+	for i, cfg := range r.configs {
+		adjust := min(max(-owed[cfg.SchedulerName], -cfg.MaximumBindings), cfg.MaximumBindings-1)
+		r.configs[i].BindingsRead = adjust
+	}
 }
 
 // next function is to return the next queue from where we should read. this
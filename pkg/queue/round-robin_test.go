@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestRoundRobinReader_Read(t *testing.T) {
@@ -57,6 +58,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 				{},
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod3"},
@@ -64,6 +66,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod4"},
@@ -74,6 +77,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 			},
 			want: []v1alpha1.PlacementRequest{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod3"},
@@ -81,6 +85,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod4"},
@@ -135,6 +140,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 			prs: [][]v1alpha1.PlacementRequest{
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod1"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod1"},
@@ -142,6 +148,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod2"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod2"},
@@ -151,6 +158,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 				},
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod3"},
@@ -158,6 +166,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod4"},
@@ -168,6 +177,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 			},
 			want: []v1alpha1.PlacementRequest{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod1"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod1"},
@@ -175,6 +185,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod2"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod2"},
@@ -182,6 +193,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod3"},
@@ -189,6 +201,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod4"},
@@ -228,6 +241,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 			prs: [][]v1alpha1.PlacementRequest{
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod1"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod1"},
@@ -235,6 +249,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod2"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod2"},
@@ -244,6 +259,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 				},
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod3"},
@@ -251,6 +267,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod4"},
@@ -260,6 +277,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 				},
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod5"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod5"},
@@ -267,6 +285,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod6"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod6"},
@@ -277,6 +296,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 			},
 			want: []v1alpha1.PlacementRequest{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod1"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod1"},
@@ -284,6 +304,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod3"},
@@ -291,6 +312,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod5"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod5"},
@@ -298,6 +320,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod2"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod2"},
@@ -305,6 +328,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod4"},
@@ -312,6 +336,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod6"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod6"},
@@ -343,6 +368,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 			prs: [][]v1alpha1.PlacementRequest{
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod1"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod1"},
@@ -350,6 +376,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod2"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod2"},
@@ -359,6 +386,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 				},
 				{
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod3"},
@@ -366,6 +394,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 						},
 					},
 					{
+						ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 						Spec: v1alpha1.PlacementRequestSpec{
 							Bindings: []v1alpha1.Binding{
 								{PodName: "pod4"},
@@ -376,6 +405,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 			},
 			want: []v1alpha1.PlacementRequest{
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod3"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod3"},
@@ -383,6 +413,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod4"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod4"},
@@ -390,6 +421,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod1"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod1"},
@@ -397,6 +429,7 @@ func TestRoundRobinReader_Read(t *testing.T) {
 					},
 				},
 				{
+					ObjectMeta: metav1.ObjectMeta{Name: "pod2"},
 					Spec: v1alpha1.PlacementRequestSpec{
 						Bindings: []v1alpha1.Binding{
 							{PodName: "pod2"},
@@ -0,0 +1,167 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"container/heap"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// PlacementRequestQueueOption is a function that modifies a
+// PlacementRequestQueue configuration.
+type PlacementRequestQueueOption func(*PlacementRequestQueue)
+
+// WithTenancy splits the queue into sub-queues, one per tenant.
+func WithTenancy(tenancy *Tenancy) PlacementRequestQueueOption {
+	return func(q *PlacementRequestQueue) {
+		q.tenancy = tenancy
+	}
+}
+
+// Tenancy defines how PlacementRequests in a queue are split among tenants.
+// Tenants are served in proportion to their weights, the priority of a
+// PlacementRequest only matters among the ones of the same tenant. A nil
+// Tenancy places all PlacementRequests under a single tenant.
+type Tenancy struct {
+	// Label is the PlacementRequest label holding the tenant name. If
+	// empty the PlacementRequest namespace is used instead.
+	Label string
+
+	// DefaultWeight is the weight of the tenants not present in Weights.
+	DefaultWeight uint
+
+	// Weights holds the weight of each tenant.
+	Weights map[string]uint
+}
+
+// tenant returns the tenant the PlacementRequest belongs to. PlacementRequests
+// without the tenant label belong to the "" tenant.
+func (t *Tenancy) tenant(pr *v1alpha1.PlacementRequest) string {
+	if t == nil {
+		return ""
+	}
+	if t.Label == "" {
+		return pr.Namespace
+	}
+	return pr.Labels[t.Label]
+}
+
+// weight returns the weight of the provided tenant, never less than one.
+func (t *Tenancy) weight(tenant string) uint {
+	if t == nil {
+		return 1
+	}
+	if weight, ok := t.Weights[tenant]; ok && weight > 0 {
+		return weight
+	}
+	return max(t.DefaultWeight, 1)
+}
+
+// NewTenancyFromV1Alpha1 converts the tenancy of a queue as present in the
+// controller configuration. Returns nil if the provided tenancy is nil.
+func NewTenancyFromV1Alpha1(cfg *configapi.Tenancy) *Tenancy {
+	if cfg == nil {
+		return nil
+	}
+	return &Tenancy{
+		Label:         cfg.Label,
+		DefaultWeight: cfg.DefaultWeight,
+		Weights:       cfg.Weights,
+	}
+}
+
+// tenantQueue is the sub-queue holding the PlacementRequests of a tenant. The
+// virtual time advances as the tenant is served, slower for tenants with a
+// larger weight. Besides the priority queue we keep the PlacementRequests
+// sorted by arrival so the ones waiting for too long can be found.
+type tenantQueue struct {
+	name     string
+	weight   uint
+	queue    *PriorityQueue
+	arrivals *PriorityQueue
+	vtime    float64
+}
+
+// push adds the PlacementRequest to the tenant queue.
+func (t *tenantQueue) push(item *PrioritizedPlacementRequest) {
+	heap.Push(t.queue, item)
+	heap.Push(t.arrivals, &arrival{item})
+}
+
+// remove removes the PlacementRequest with the provided key from the tenant
+// queue and returns it. Returns nil if it is not found.
+func (t *tenantQueue) remove(key string) *PrioritizedPlacementRequest {
+	idx, found := t.queue.Index(key)
+	if !found {
+		return nil
+	}
+
+	item := heap.Remove(t.queue, idx).(*PrioritizedPlacementRequest)
+	if idx, found := t.arrivals.Index(key); found {
+		heap.Remove(t.arrivals, idx)
+	}
+	return item
+}
+
+// head returns the PlacementRequest at the head of the tenant queue. Must not
+// be called on an empty queue.
+func (t *tenantQueue) head() *PrioritizedPlacementRequest {
+	head, ok := t.queue.Get(0).(*PrioritizedPlacementRequest)
+	if !ok || head.PlacementRequest == nil {
+		panic("PlacementRequest queue found an unexpected object")
+	}
+	return head
+}
+
+// oldest returns the PlacementRequest that has been in the tenant queue for
+// the longest. Must not be called on an empty queue.
+func (t *tenantQueue) oldest() *PrioritizedPlacementRequest {
+	oldest, ok := t.arrivals.Get(0).(*arrival)
+	if !ok {
+		panic("PlacementRequest queue found an unexpected object")
+	}
+	return oldest.PrioritizedPlacementRequest
+}
+
+// ahead returns true if the head of this tenant queue should be served before
+// the head of the other one, following the same order used within a queue.
+func (t *tenantQueue) ahead(other *tenantQueue) bool {
+	head, ohead := t.head(), other.head()
+	if head.Priority() != ohead.Priority() {
+		return head.Priority() > ohead.Priority()
+	}
+	return head.Before(ohead)
+}
+
+// charge advances the tenant virtual time by the number of bindings in the
+// PlacementRequest relative to the tenant weight.
+func (t *tenantQueue) charge(pr *v1alpha1.PlacementRequest) {
+	bindings := max(len(pr.Spec.Bindings), 1)
+	t.vtime += float64(bindings) / float64(t.weight)
+}
+
+// newTenantQueue returns an empty queue for the provided tenant.
+func newTenantQueue(name string, weight uint) *tenantQueue {
+	return &tenantQueue{
+		name:     name,
+		weight:   weight,
+		queue:    newPriorityQueue(),
+		arrivals: newPriorityQueue(),
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// tenantPlacementRequest returns a placement request with a single binding
+// in the provided namespace.
+func tenantPlacementRequest(namespace string, idx int) *v1alpha1.PlacementRequest {
+	pr := sizedPlacementRequest(namespace, idx, 1)
+	pr.Namespace = namespace
+	return pr
+}
+
+// popNamespaces pops the provided amount of placement requests and returns
+// how many of them came from each namespace.
+func popNamespaces(queue *PlacementRequestQueue, count int) map[string]int {
+	result := map[string]int{}
+	for range count {
+		pr := queue.Pop()
+		if pr == nil {
+			break
+		}
+		result[pr.Namespace]++
+	}
+	return result
+}
+
+func TestPlacementRequestQueueWithoutTenancy(t *testing.T) {
+	require := require.New(t)
+
+	queue := NewPlacementRequestQueue()
+	for i := range 10 {
+		queue.Push(tenantPlacementRequest("a", i))
+	}
+	for i := range 10 {
+		queue.Push(tenantPlacementRequest("b", i))
+	}
+
+	// a single tenant means the flooding namespace is served first.
+	require.Equal(map[string]int{"a": 10}, popNamespaces(queue, 10))
+}
+
+func TestPlacementRequestQueueTenancyByNamespace(t *testing.T) {
+	require := require.New(t)
+
+	queue := NewPlacementRequestQueue(WithTenancy(&Tenancy{}))
+	for i := range 30 {
+		queue.Push(tenantPlacementRequest("a", i))
+	}
+	for i := range 10 {
+		queue.Push(tenantPlacementRequest("b", i))
+	}
+	require.Equal(40, queue.Len())
+
+	require.Equal(map[string]int{"a": 10, "b": 10}, popNamespaces(queue, 20))
+	require.Equal(map[string]int{"a": 20}, popNamespaces(queue, 30))
+	require.Zero(queue.Len())
+	require.Nil(queue.Peek())
+}
+
+func TestPlacementRequestQueueTenancyWeights(t *testing.T) {
+	require := require.New(t)
+
+	queue := NewPlacementRequestQueue(WithTenancy(&Tenancy{
+		DefaultWeight: 1,
+		Weights:       map[string]uint{"a": 3},
+	}))
+	for i := range 40 {
+		queue.Push(tenantPlacementRequest("a", i))
+		queue.Push(tenantPlacementRequest("b", i))
+	}
+
+	require.Equal(map[string]int{"a": 30, "b": 10}, popNamespaces(queue, 40))
+}
+
+func TestPlacementRequestQueueTenancyIdle(t *testing.T) {
+	require := require.New(t)
+
+	queue := NewPlacementRequestQueue(WithTenancy(&Tenancy{}))
+	for i := range 40 {
+		queue.Push(tenantPlacementRequest("a", i))
+	}
+	require.Equal(map[string]int{"a": 20}, popNamespaces(queue, 20))
+
+	// a tenant arriving late can't claim the time it spent idle, it
+	// shares from now on instead of starving the other one.
+	for i := range 20 {
+		queue.Push(tenantPlacementRequest("b", i))
+	}
+	require.Equal(map[string]int{"a": 5, "b": 5}, popNamespaces(queue, 10))
+}
+
+func TestPlacementRequestQueueTenancyByLabel(t *testing.T) {
+	require := require.New(t)
+
+	queue := NewPlacementRequestQueue(WithTenancy(&Tenancy{Label: "team"}))
+
+	first := tenantPlacementRequest("ns", 0)
+	first.Labels = map[string]string{"team": "a"}
+	second := tenantPlacementRequest("ns", 1)
+	second.Labels = map[string]string{"team": "a"}
+	third := tenantPlacementRequest("ns", 2)
+	third.Labels = map[string]string{"team": "b"}
+	for _, pr := range []*v1alpha1.PlacementRequest{first, second, third} {
+		queue.Push(pr)
+	}
+
+	require.Equal("ns-0", queue.Pop().Name)
+	require.Equal("ns-2", queue.Pop().Name)
+
+	// moving a placement request to another tenant keeps a single copy.
+	moved := second.DeepCopy()
+	moved.Labels["team"] = "b"
+	queue.Push(moved)
+	require.Equal(1, queue.Len())
+	require.True(queue.Has(PlacementRequestKey(moved)))
+
+	require.True(queue.Remove(PlacementRequestKey(moved)))
+	require.False(queue.Has(PlacementRequestKey(moved)))
+	require.Zero(queue.Len())
+	require.Nil(queue.Pop())
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// TieredReader implements strict priority among tiers of queues. Each tier
+// has its own reader, created through the configured ReaderFactory, so the
+// fairness algorithm only applies among queues in the same tier. A tier is
+// only read from once all the tiers above it are empty.
+type TieredReader struct {
+	readers []Reader
+}
+
+// This global variable is used to ensure that the TieredReader implements the
+// StatefulReader interface.
+var _ StatefulReader = &TieredReader{}
+
+// SetFairnessState hands the fairness history over to the readers of each
+// tier that support it.
+func (t *TieredReader) SetFairnessState(state *FairnessState) {
+	for _, reader := range t.readers {
+		if stateful, ok := reader.(StatefulReader); ok {
+			stateful.SetFairnessState(state)
+		}
+	}
+}
+
+// Read returns the next PlacementRequest from the highest tier that has one.
+// Returns nil if all tiers are empty.
+func (t *TieredReader) Read(ctx context.Context) *v1alpha1.PlacementRequest {
+	for _, reader := range t.readers {
+		if pr := reader.Read(ctx); pr != nil {
+			return pr
+		}
+	}
+	return nil
+}
+
+// NewTieredReaderFactory returns a ReaderFactory that splits the queues into
+// the provided tiers, ordered from the highest to the lowest, and creates a
+// reader for each one of them using the provided factory. Queues without a
+// tier, or with a tier not in the list, are placed below all tiers. Tiers
+// without queues are ignored.
+func NewTieredReaderFactory(tiers []string, factory ReaderFactory) ReaderFactory {
+	return func(configs QueueConfigs) Reader {
+		bytier := map[string]QueueConfigs{}
+		for _, cfg := range configs {
+			bytier[cfg.Tier] = append(bytier[cfg.Tier], cfg)
+		}
+
+		reader := &TieredReader{}
+		for _, tier := range tiers {
+			if cfgs := bytier[tier]; len(cfgs) > 0 {
+				reader.readers = append(reader.readers, factory(cfgs))
+				delete(bytier, tier)
+			}
+		}
+
+		// whatever is left goes into the lowest tier, we keep the
+		// original order of the queues.
+		var lowest QueueConfigs
+		for _, cfg := range configs {
+			if _, ok := bytier[cfg.Tier]; ok {
+				lowest = append(lowest, cfg)
+			}
+		}
+		if len(lowest) > 0 {
+			reader.readers = append(reader.readers, factory(lowest))
+		}
+
+		return reader
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configv1alpha1 "kombiner/pkg/apis/config/v1alpha1"
+)
+
+func TestTieredReader(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "untiered", Weight: 100},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "batch", Weight: 100, Tier: "batch"},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "system-a", Weight: 1, Tier: "system"},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "system-b", Weight: 1, Tier: "system"},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	for _, cfg := range configs {
+		for i := range 2 {
+			cfg.QueueRef.Push(sizedPlacementRequest(cfg.SchedulerName, i, 1))
+		}
+	}
+
+	factory := NewTieredReaderFactory([]string{"system", "batch"}, NewWeightedFairQueueingReaderFactory())
+	reader := factory(configs)
+
+	var order []string
+	for range 4 {
+		pr := reader.Read(context.Background())
+		require.NotNil(pr)
+		order = append(order, pr.Spec.SchedulerName)
+	}
+	require.ElementsMatch([]string{"system-a", "system-a", "system-b", "system-b"}, order)
+
+	// a request pushed to a higher tier jumps ahead of the lower tiers.
+	require.Equal("batch", reader.Read(context.Background()).Spec.SchedulerName)
+	configs[2].QueueRef.Push(sizedPlacementRequest("system-a", 3, 1))
+	require.Equal("system-a", reader.Read(context.Background()).Spec.SchedulerName)
+	require.Equal("batch", reader.Read(context.Background()).Spec.SchedulerName)
+
+	require.Equal("untiered", reader.Read(context.Background()).Spec.SchedulerName)
+	require.Equal("untiered", reader.Read(context.Background()).Spec.SchedulerName)
+	require.Nil(reader.Read(context.Background()))
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// VirtualClock holds the state of the WeightedFairQueueingReader. It keeps the
+// current virtual time, the virtual finish time of the last PlacementRequest
+// served from each queue and the virtual start time of the PlacementRequests
+// waiting at the head of each queue. It outlives the readers so the virtual
+// time does not go back when the QueueIterator creates a new reader.
+type VirtualClock struct {
+	Now    float64
+	Finish map[string]float64
+	Start  map[string]float64
+}
+
+// WeightedFairQueueingReader implements a self clocked variant of Weighted
+// Fair Queueing. The PlacementRequest at the head of each queue is stamped
+// with a virtual finish time: it starts at the virtual time it reached the
+// head or when the last PlacementRequest of its queue finished, whichever is
+// later, and lasts its number of bindings divided by its queue weight. The
+// PlacementRequest with the smallest finish time is served and the virtual
+// time moves to it. This gives each queue a share of the bindings proportional
+// to its weight and bounds how long a PlacementRequest waits at the head of
+// its queue.
+type WeightedFairQueueingReader struct {
+	configs QueueConfigs
+	clock   *VirtualClock
+}
+
+// Read returns the PlacementRequest with the smallest virtual finish time or
+// nil if all queues are empty.
+func (w *WeightedFairQueueingReader) Read(_ context.Context) *v1alpha1.PlacementRequest {
+	for {
+		selected := -1
+		var head *v1alpha1.PlacementRequest
+		var finish float64
+		for i, cfg := range w.configs {
+			pr := cfg.QueueRef.Peek()
+			if pr == nil {
+				// an idle queue must not save up, once it gets
+				// something again it starts at the virtual time.
+				delete(w.clock.Start, cfg.SchedulerName)
+				continue
+			}
+
+			if stamp := w.stamp(cfg, pr); selected < 0 || stamp < finish {
+				selected, head, finish = i, pr, stamp
+			}
+		}
+
+		if selected < 0 {
+			return nil
+		}
+
+		// the head of the queue may have changed since we peeked (e.g. a
+		// higher priority request was pushed), if so we start over.
+		pr := w.configs[selected].QueueRef.PopIf(
+			func(pr *v1alpha1.PlacementRequest) bool {
+				return pr == head
+			},
+		)
+		if pr == nil {
+			continue
+		}
+
+		name := w.configs[selected].SchedulerName
+		w.clock.Now = finish
+		w.clock.Finish[name] = finish
+		delete(w.clock.Start, name)
+		return pr
+	}
+}
+
+// stamp returns the virtual finish time for the provided PlacementRequest at
+// the head of the provided queue. The start time is recorded the first time
+// we see the queue head so it does not move while it waits to be served. If
+// the head is replaced (e.g. by a higher priority request) the newcomer keeps
+// the same start time.
+func (w *WeightedFairQueueingReader) stamp(cfg QueueConfig, pr *v1alpha1.PlacementRequest) float64 {
+	start, ok := w.clock.Start[cfg.SchedulerName]
+	if !ok {
+		start = max(w.clock.Now, w.clock.Finish[cfg.SchedulerName])
+		w.clock.Start[cfg.SchedulerName] = start
+	}
+
+	size := max(len(pr.Spec.Bindings), 1)
+	return start + float64(size)/float64(cfg.Weight)
+}
+
+// NewWeightedFairQueueingReader creates a WeightedFairQueueingReader for the
+// provided queues using the provided virtual clock.
+func NewWeightedFairQueueingReader(configs QueueConfigs, clock *VirtualClock) Reader {
+	if clock.Finish == nil {
+		clock.Finish = map[string]float64{}
+	}
+	if clock.Start == nil {
+		clock.Start = map[string]float64{}
+	}
+
+	for _, cfg := range configs {
+		// this should never happen as configs are validated.
+		if cfg.Weight == 0 {
+			panic("queue with zero weight provided")
+		}
+	}
+
+	return &WeightedFairQueueingReader{
+		configs: configs,
+		clock:   clock,
+	}
+}
+
+// NewWeightedFairQueueingReaderFactory returns a ReaderFactory whose readers
+// share the same virtual clock. This keeps the virtual time across the
+// multiple readers a QueueIterator creates.
+func NewWeightedFairQueueingReaderFactory() ReaderFactory {
+	clock := &VirtualClock{}
+	return func(configs QueueConfigs) Reader {
+		return NewWeightedFairQueueingReader(configs, clock)
+	}
+}
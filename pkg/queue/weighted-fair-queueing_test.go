@@ -0,0 +1,111 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	configv1alpha1 "kombiner/pkg/apis/config/v1alpha1"
+)
+
+func TestWeightedFairQueueingReaderOrder(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 2},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "b", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	for i := range 3 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 2))
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", i, 1))
+	}
+
+	// finish times are a: 1, 2, 3 and b: 1, 2, 3. ties go to the first
+	// queue.
+	reader := NewWeightedFairQueueingReader(configs, &VirtualClock{})
+	var order []string
+	for pr := reader.Read(context.Background()); pr != nil; pr = reader.Read(context.Background()) {
+		order = append(order, pr.Name)
+	}
+	require.Equal([]string{"a-0", "b-0", "a-1", "b-1", "a-2", "b-2"}, order)
+}
+
+func TestWeightedFairQueueingReaderShare(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 5},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "b", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	for i := range 3000 {
+		configs[0].QueueRef.Push(sizedPlacementRequest("a", i, 1))
+	}
+	for i := range 1000 {
+		configs[1].QueueRef.Push(sizedPlacementRequest("b", i, 7))
+	}
+
+	reader := NewWeightedFairQueueingReader(configs, &VirtualClock{})
+	served := map[string]int{}
+	for range 1800 {
+		pr := reader.Read(context.Background())
+		require.NotNil(pr)
+		served[pr.Spec.SchedulerName] += len(pr.Spec.Bindings)
+	}
+
+	ratio := float64(served["a"]) / float64(served["b"])
+	require.InDelta(5.0, ratio, 0.2, "unexpected share: %v", served)
+}
+
+func TestWeightedFairQueueingReaderFactoryKeepsClock(t *testing.T) {
+	require := require.New(t)
+
+	configs := QueueConfigs{
+		{
+			Queue:    configv1alpha1.Queue{SchedulerName: "a", Weight: 1},
+			QueueRef: NewPlacementRequestQueue(),
+		},
+	}
+
+	factory := NewWeightedFairQueueingReaderFactory()
+	configs[0].QueueRef.Push(sizedPlacementRequest("a", 0, 4))
+	first := factory(configs)
+	require.NotNil(first.Read(context.Background()))
+	require.Nil(first.Read(context.Background()))
+
+	// a new reader, as created when the iterator resumes, carries on
+	// from where the previous one stopped.
+	second := factory(configs).(*WeightedFairQueueingReader)
+	require.Equal(4.0, second.clock.Now)
+	require.Equal(4.0, second.clock.Finish["a"])
+}
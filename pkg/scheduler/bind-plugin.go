@@ -40,6 +40,11 @@ import (
 // one for the same pod.
 const DeletePlacementRequestTimeout = time.Second
 
+// PollInterval is how often we read the placement request while waiting for
+// its result. It must stay well below v1alpha1.MinTTLSecondsAfterFinished or
+// the controller may delete the placement request before we read it.
+const PollInterval = time.Second
+
 // this global variable is used to ensure, at compile time, that the BindPlugin
 // struct complies with the expected framework interface.
 var _ framework.BindPlugin = &BindPlugin{}
@@ -76,10 +81,18 @@ func (p *BindPlugin) Bind(
 		schedulerName = corev1.DefaultSchedulerName
 	}
 
+	var priority v1alpha1.PlacementRequestPriority
+	if p.config.UsePodPriority && pod.Spec.Priority != nil {
+		priority = v1alpha1.PlacementRequestPriority(*pod.Spec.Priority)
+	}
+
 	pr := &v1alpha1.PlacementRequest{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      prname,
 			Namespace: pod.Namespace,
+			// the controller may split its queues by tenant based
+			// on a label so we carry the pod labels over.
+			Labels: pod.Labels,
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: corev1.SchemeGroupVersion.String(),
@@ -91,7 +104,7 @@ func (p *BindPlugin) Bind(
 		},
 		Spec: v1alpha1.PlacementRequestSpec{
 			Policy:        v1alpha1.PlacementRequestPolicyLenient,
-			Priority:      0,
+			Priority:      priority,
 			SchedulerName: schedulerName,
 			Bindings: []v1alpha1.Binding{
 				{
@@ -127,7 +140,7 @@ func (p *BindPlugin) Bind(
 	// XXX we are simply polling here but this is wrong in so many levels.
 	// the amount of things to be improved here is huge.
 	if err := wait.PollUntilContextCancel(
-		timeout, time.Second, true,
+		timeout, PollInterval, true,
 		func(ctx context.Context) (bool, error) {
 			var err error
 			pr, err = client.Get(ctx, prname, metav1.GetOptions{})
@@ -33,6 +33,7 @@ import (
 	"k8s.io/klog/v2/ktesting"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
 	st "k8s.io/kubernetes/pkg/scheduler/testing"
+	"k8s.io/utils/ptr"
 
 	"kombiner/pkg/apis/kombiner/v1alpha1"
 	"kombiner/pkg/apis/scheduler"
@@ -133,3 +134,83 @@ func TestBindPluginSchedulerNameSet(t *testing.T) {
 		})
 	}
 }
+
+func TestBindPluginUsePodPriority(t *testing.T) {
+	tests := []struct {
+		name             string
+		usePodPriority   bool
+		podPriority      *int32
+		expectedPriority v1alpha1.PlacementRequestPriority
+	}{
+		{
+			name:             "pod priority disabled",
+			podPriority:      ptr.To[int32](1000),
+			expectedPriority: 0,
+		},
+		{
+			name:             "pod priority enabled",
+			usePodPriority:   true,
+			podPriority:      ptr.To[int32](1000),
+			expectedPriority: 1000,
+		},
+		{
+			name:             "pod without priority",
+			usePodPriority:   true,
+			expectedPriority: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ctx := ktesting.NewTestContext(t)
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			client := fake.NewSimpleClientset()
+
+			testPod := st.MakePod().Name("foo").Namespace("ns").UID("foo-uid").Obj()
+			testPod.Spec.Priority = tt.podPriority
+
+			config := &scheduler.PlacementRequestBinderArgs{UsePodPriority: tt.usePodPriority}
+			scheduler.SetDefaults(config)
+
+			bindDoneChan := make(chan struct{})
+			go func() {
+				binder := &BindPlugin{
+					client: client,
+					logger: klog.New(nil),
+					config: config,
+				}
+				binder.Bind(ctx, nil, testPod, "testNode")
+				bindDoneChan <- struct{}{}
+			}()
+
+			prClient := client.KombinerV1alpha1().PlacementRequests(testPod.Namespace)
+			if err := wait.PollUntilContextTimeout(
+				ctx, 50*time.Millisecond, time.Second, true,
+				func(ctx context.Context) (bool, error) {
+					pr, err := prClient.Get(ctx, string(testPod.UID), metav1.GetOptions{})
+					if err != nil {
+						if errors.IsNotFound(err) {
+							return false, nil
+						}
+						return false, err
+					}
+
+					if diff := cmp.Diff(tt.expectedPriority, pr.Spec.Priority); diff != "" {
+						t.Errorf("got different priority (-want, +got): %s", diff)
+					}
+
+					pr.Status = v1alpha1.PlacementRequestStatus{
+						Result: v1alpha1.PlacementRequestResultSuccess,
+					}
+					_, err = prClient.Update(ctx, pr, metav1.UpdateOptions{})
+					return err == nil, err
+				},
+			); err != nil {
+				t.Fatal(err)
+			}
+
+			<-bindDoneChan
+		})
+	}
+}
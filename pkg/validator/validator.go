@@ -0,0 +1,350 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	schedconfig "k8s.io/kubernetes/pkg/scheduler/apis/config"
+	"k8s.io/kubernetes/pkg/scheduler/apis/config/latest"
+	"k8s.io/kubernetes/pkg/scheduler/backend/cache"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/names"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	schedmetrics "k8s.io/kubernetes/pkg/scheduler/metrics"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+)
+
+// FilterPlugins holds the names of the in-tree scheduler plugins that can be
+// used as validators, in the order the default scheduler profile runs them.
+var FilterPlugins = []string{
+	names.NodeUnschedulable,
+	names.NodeName,
+	names.TaintToleration,
+	names.NodeAffinity,
+	names.NodePorts,
+	names.NodeResourcesFit,
+	names.VolumeRestrictions,
+	names.NodeVolumeLimits,
+	names.VolumeBinding,
+	names.VolumeZone,
+	names.PodTopologySpread,
+	names.InterPodAffinity,
+}
+
+// EnabledPlugins merges the cluster wide and the queue plugin configurations
+// and returns the plugins to be run as validators for the queue. Plugins
+// enabled in either of them are run unless they are disabled in any of
+// them, i.e. a plugin disabled cluster wide can't be enabled for a queue.
+func EnabledPlugins(global, queue configapi.Plugins) []string {
+	disabled := sets.New(global.Validate.Disabled...).Insert(queue.Validate.Disabled...)
+
+	var enabled []string
+	for _, name := range slices.Concat(global.Validate.Enabled, queue.Validate.Enabled) {
+		if disabled.Has(name) || slices.Contains(enabled, name) {
+			continue
+		}
+		enabled = append(enabled, name)
+	}
+	return enabled
+}
+
+// ValidationError is returned when a plugin finds a binding is not valid.
+type ValidationError struct {
+	Plugin  string
+	Message string
+}
+
+// Error returns the error message.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Plugin, e.Message)
+}
+
+// Binding is a pod and the node it is about to be bound to.
+type Binding struct {
+	Pod      *corev1.Pod
+	NodeName string
+}
+
+// Validator runs scheduler Filter plugins against bindings before they are
+// bound. The plugins run inside a scheduler framework whose snapshot of the
+// cluster comes out of a scheduler cache. Like in the scheduler the cache is
+// kept up to date by the pod and node informer events and the snapshot is
+// updated incrementally, only with the nodes that changed, every time we
+// validate.
+type Validator struct {
+	mtx       sync.RWMutex
+	logger    klog.Logger
+	framework framework.Framework
+	snapshot  *snapshotLister
+	cache     cache.Cache
+	synced    []toolscache.InformerSynced
+}
+
+// New returns a Validator running the provided plugins. Plugins use the
+// informer factory to reach for the objects they need (services, volumes,
+// namespaces, etc) so the factory must be started after this is called.
+// Plugin arguments are the defaults of the scheduler default profile. The
+// provided context bounds the lifetime of the validator cache.
+func New(ctx context.Context, enabled []string, factory informers.SharedInformerFactory) (*Validator, error) {
+	for _, name := range enabled {
+		if !slices.Contains(FilterPlugins, name) {
+			return nil, fmt.Errorf("unknown validate plugin %q", name)
+		}
+	}
+
+	defaults, err := latest.Default()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default scheduler configuration: %w", err)
+	}
+
+	// a framework refuses to be created without a queue sort and a bind
+	// plugin. they are never used.
+	multipoint := make([]schedconfig.Plugin, len(enabled))
+	for i, name := range enabled {
+		multipoint[i] = schedconfig.Plugin{Name: name}
+	}
+	profile := &schedconfig.KubeSchedulerProfile{
+		SchedulerName: "kombiner-validator",
+		Plugins: &schedconfig.Plugins{
+			QueueSort:  schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: names.PrioritySort}}},
+			Bind:       schedconfig.PluginSet{Enabled: []schedconfig.Plugin{{Name: names.DefaultBinder}}},
+			MultiPoint: schedconfig.PluginSet{Enabled: multipoint},
+		},
+		PluginConfig: defaults.Profiles[0].PluginConfig,
+	}
+
+	// the framework expects the scheduler metrics to exist. they are
+	// registered in the legacy registry, we don't expose them.
+	schedmetrics.Register()
+
+	snapshot := &snapshotLister{snapshot: cache.NewEmptySnapshot()}
+	fwk, err := frameworkruntime.NewFramework(
+		ctx, plugins.NewInTreeRegistry(), profile,
+		frameworkruntime.WithInformerFactory(factory),
+		frameworkruntime.WithSnapshotSharedLister(snapshot),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduler framework: %w", err)
+	}
+
+	validator := &Validator{
+		logger:    klog.FromContext(ctx),
+		framework: fwk,
+		snapshot:  snapshot,
+		cache:     cache.New(ctx, 0),
+	}
+	if err := validator.addEventHandlers(factory); err != nil {
+		return nil, err
+	}
+	return validator, nil
+}
+
+// Validate runs the plugins for each one of the provided bindings and returns
+// an error for each one of them, nil if the binding is valid. All bindings
+// are validated against the same snapshot of the cluster and they are not
+// accounted against each other. Plugins rejecting a binding are reported
+// through a ValidationError. Validations run concurrently, only updating
+// the snapshot is serialized.
+func (v *Validator) Validate(ctx context.Context, bindings []Binding) []error {
+	errs := make([]error, len(bindings))
+	if err := v.refresh(ctx); err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	v.mtx.RLock()
+	defer v.mtx.RUnlock()
+	for i, binding := range bindings {
+		errs[i] = v.validate(ctx, binding)
+	}
+	return errs
+}
+
+// validate runs the PreFilter and Filter plugins for a single binding.
+func (v *Validator) validate(ctx context.Context, binding Binding) error {
+	nodeInfo, err := v.snapshot.NodeInfos().Get(binding.NodeName)
+	if err != nil {
+		return fmt.Errorf("failed to get node %s from snapshot: %w", binding.NodeName, err)
+	}
+
+	state := framework.NewCycleState()
+	result, status, rejectors := v.framework.RunPreFilterPlugins(ctx, state, binding.Pod)
+	if !status.IsSuccess() {
+		// when the result filters out all nodes the status does not
+		// carry the plugin, we get it from the rejectors instead.
+		if status.Plugin() == "" {
+			status.SetPlugin(strings.Join(sets.List(rejectors), ","))
+		}
+		return statusError(status)
+	}
+
+	if !result.AllNodes() && !result.NodeNames.Has(binding.NodeName) {
+		return &ValidationError{
+			Plugin:  strings.Join(sets.List(rejectors), ","),
+			Message: fmt.Sprintf("node %s is not eligible for the pod", binding.NodeName),
+		}
+	}
+
+	if status := v.framework.RunFilterPlugins(ctx, state, binding.Pod, nodeInfo); !status.IsSuccess() {
+		return statusError(status)
+	}
+	return nil
+}
+
+// refresh brings the snapshot up to date with the cache. Before the first
+// refresh we wait for the event handlers to have seen everything in the
+// informer caches.
+func (v *Validator) refresh(ctx context.Context) error {
+	if !toolscache.WaitForCacheSync(ctx.Done(), v.synced...) {
+		return fmt.Errorf("failed to sync validator cache: %w", ctx.Err())
+	}
+
+	v.mtx.Lock()
+	defer v.mtx.Unlock()
+	if err := v.cache.UpdateSnapshot(v.logger, v.snapshot.snapshot); err != nil {
+		return fmt.Errorf("failed to update snapshot: %w", err)
+	}
+	return nil
+}
+
+// addEventHandlers keeps the cache in sync with the informers. As the
+// scheduler does only pods assigned to a node and not terminated are taken
+// into account.
+func (v *Validator) addEventHandlers(factory informers.SharedInformerFactory) error {
+	pods, err := factory.Core().V1().Pods().Informer().AddEventHandler(
+		toolscache.FilteringResourceEventHandler{
+			FilterFunc: func(obj interface{}) bool {
+				switch t := obj.(type) {
+				case *corev1.Pod:
+					return assignedPod(t)
+				case toolscache.DeletedFinalStateUnknown:
+					_, ok := t.Obj.(*corev1.Pod)
+					return ok
+				default:
+					return false
+				}
+			},
+			Handler: toolscache.ResourceEventHandlerFuncs{
+				AddFunc: func(obj interface{}) {
+					if err := v.cache.AddPod(v.logger, obj.(*corev1.Pod)); err != nil {
+						v.logger.V(5).Info("failed to add pod to validator cache", "error", err.Error())
+					}
+				},
+				UpdateFunc: func(oldobj, newobj interface{}) {
+					if err := v.cache.UpdatePod(v.logger, oldobj.(*corev1.Pod), newobj.(*corev1.Pod)); err != nil {
+						v.logger.V(5).Info("failed to update pod in validator cache", "error", err.Error())
+					}
+				},
+				DeleteFunc: func(obj interface{}) {
+					if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+						obj = tombstone.Obj
+					}
+					if err := v.cache.RemovePod(v.logger, obj.(*corev1.Pod)); err != nil {
+						v.logger.V(5).Info("failed to remove pod from validator cache", "error", err.Error())
+					}
+				},
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add pod event handler: %w", err)
+	}
+
+	nodes, err := factory.Core().V1().Nodes().Informer().AddEventHandler(
+		toolscache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				if node, ok := obj.(*corev1.Node); ok {
+					v.cache.AddNode(v.logger, node)
+				}
+			},
+			UpdateFunc: func(oldobj, newobj interface{}) {
+				old, ok := oldobj.(*corev1.Node)
+				node, nok := newobj.(*corev1.Node)
+				if ok && nok {
+					v.cache.UpdateNode(v.logger, old, node)
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				node, ok := obj.(*corev1.Node)
+				if !ok {
+					return
+				}
+				if err := v.cache.RemoveNode(v.logger, node); err != nil {
+					v.logger.V(5).Info("failed to remove node from validator cache", "error", err.Error())
+				}
+			},
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to add node event handler: %w", err)
+	}
+
+	v.synced = []toolscache.InformerSynced{pods.HasSynced, nodes.HasSynced}
+	return nil
+}
+
+// assignedPod returns true if the pod is bound to a node and still running or
+// about to run.
+func assignedPod(pod *corev1.Pod) bool {
+	if pod.Spec.NodeName == "" {
+		return false
+	}
+	return pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed
+}
+
+// statusError converts a non successful framework status into an error.
+func statusError(status *framework.Status) error {
+	return &ValidationError{Plugin: status.Plugin(), Message: status.Message()}
+}
+
+// This global variable is used to ensure that snapshotLister implements the
+// framework.SharedLister interface.
+var _ framework.SharedLister = &snapshotLister{}
+
+// snapshotLister gives the framework access to the latest snapshot. Plugins
+// hold on to the lister they get when created so we can't hand them the
+// snapshot directly.
+type snapshotLister struct {
+	snapshot *cache.Snapshot
+}
+
+// NodeInfos returns the node lister of the latest snapshot.
+func (s *snapshotLister) NodeInfos() framework.NodeInfoLister {
+	return s.snapshot.NodeInfos()
+}
+
+// StorageInfos returns the storage lister of the latest snapshot.
+func (s *snapshotLister) StorageInfos() framework.StorageInfoLister {
+	return s.snapshot.StorageInfos()
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+)
+
+// newTestValidator returns a validator for the provided plugins whose
+// informers are fed with the provided objects.
+func newTestValidator(t *testing.T, plugins []string, objs ...runtime.Object) *Validator {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	factory := informers.NewSharedInformerFactory(kubefake.NewClientset(objs...), 0)
+	validator, err := New(ctx, plugins, factory)
+	require.NoError(t, err)
+
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+	return validator
+}
+
+func testPod(name, node string, cpu string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID("uid-" + name)},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{
+				{
+					Name: "main",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testNode(name string, cpu string) *corev1.Node {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:  resource.MustParse(cpu),
+		corev1.ResourcePods: resource.MustParse("110"),
+	}
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     corev1.NodeStatus{Allocatable: allocatable, Capacity: allocatable},
+	}
+}
+
+func TestEnabledPlugins(t *testing.T) {
+	require := require.New(t)
+
+	global := configapi.Plugins{
+		Validate: configapi.PluginSet{
+			Enabled:  []string{"TaintToleration", "NodeAffinity"},
+			Disabled: []string{"InterPodAffinity"},
+		},
+	}
+	queue := configapi.Plugins{
+		Validate: configapi.PluginSet{
+			Enabled:  []string{"PodTopologySpread", "TaintToleration", "InterPodAffinity"},
+			Disabled: []string{"NodeAffinity"},
+		},
+	}
+
+	require.Equal([]string{"TaintToleration", "PodTopologySpread"}, EnabledPlugins(global, queue))
+	require.Equal([]string{"TaintToleration", "NodeAffinity"}, EnabledPlugins(global, configapi.Plugins{}))
+	require.Empty(EnabledPlugins(configapi.Plugins{}, configapi.Plugins{}))
+}
+
+func TestNewUnknownPlugin(t *testing.T) {
+	factory := informers.NewSharedInformerFactory(kubefake.NewClientset(), 0)
+	_, err := New(context.Background(), []string{"PrioritySort"}, factory)
+	require.Error(t, err)
+}
+
+func TestValidatorTaintToleration(t *testing.T) {
+	require := require.New(t)
+
+	tainted := testNode("tainted", "4")
+	tainted.Spec.Taints = []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+	}
+	validator := newTestValidator(t, FilterPlugins, tainted, testNode("clean", "4"))
+
+	tolerating := testPod("tolerating", "", "1")
+	tolerating.Spec.Tolerations = []corev1.Toleration{
+		{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+	}
+
+	errs := validator.Validate(
+		context.Background(),
+		[]Binding{
+			{Pod: testPod("a", "", "1"), NodeName: "tainted"},
+			{Pod: testPod("b", "", "1"), NodeName: "clean"},
+			{Pod: tolerating, NodeName: "tainted"},
+		},
+	)
+	require.Len(errs, 3)
+
+	var verr *ValidationError
+	require.ErrorAs(errs[0], &verr)
+	require.Equal("TaintToleration", verr.Plugin)
+	require.NoError(errs[1])
+	require.NoError(errs[2])
+}
+
+func TestValidatorNodeResourcesFit(t *testing.T) {
+	require := require.New(t)
+
+	// the node has room for three cpus but two of them are already taken
+	// by a running pod, finished pods do not count.
+	finished := testPod("finished", "node", "2")
+	finished.Status.Phase = corev1.PodSucceeded
+	validator := newTestValidator(
+		t, []string{"NodeResourcesFit"},
+		testNode("node", "3"), testPod("running", "node", "2"), finished,
+	)
+
+	errs := validator.Validate(
+		context.Background(),
+		[]Binding{
+			{Pod: testPod("small", "", "1"), NodeName: "node"},
+			{Pod: testPod("large", "", "2"), NodeName: "node"},
+		},
+	)
+	require.NoError(errs[0])
+
+	var verr *ValidationError
+	require.ErrorAs(errs[1], &verr)
+	require.Equal("NodeResourcesFit", verr.Plugin)
+	require.Contains(verr.Message, "Insufficient cpu")
+}
+
+func TestValidatorNodeAffinityPreFilter(t *testing.T) {
+	require := require.New(t)
+
+	// node affinity on the node name is evaluated during PreFilter, the
+	// node is not even considered by the Filter plugins.
+	validator := newTestValidator(t, []string{"NodeAffinity"}, testNode("a", "1"), testNode("b", "1"))
+	pod := testPod("pod", "", "1")
+	pod.Spec.Affinity = &corev1.Affinity{
+		NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+				NodeSelectorTerms: []corev1.NodeSelectorTerm{
+					{
+						MatchFields: []corev1.NodeSelectorRequirement{
+							{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	errs := validator.Validate(
+		context.Background(),
+		[]Binding{{Pod: pod, NodeName: "a"}, {Pod: pod, NodeName: "b"}},
+	)
+	require.NoError(errs[0])
+
+	var verr *ValidationError
+	require.ErrorAs(errs[1], &verr)
+	require.Equal("NodeAffinity", verr.Plugin)
+}
+
+func TestValidatorFollowsEvents(t *testing.T) {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	kubecli := kubefake.NewClientset(testNode("node", "2"))
+	factory := informers.NewSharedInformerFactory(kubecli, 0)
+	validator, err := New(ctx, []string{"NodeResourcesFit"}, factory)
+	require.NoError(err)
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	fits := func() bool {
+		errs := validator.Validate(ctx, []Binding{{Pod: testPod("pod", "", "1"), NodeName: "node"}})
+		return errs[0] == nil
+	}
+	require.True(fits())
+
+	// a pod taking all the node cpu shows up.
+	running := testPod("running", "node", "2")
+	running, err = kubecli.CoreV1().Pods("ns").Create(ctx, running, metav1.CreateOptions{})
+	require.NoError(err)
+	require.Eventually(func() bool { return !fits() }, 5*time.Second, 10*time.Millisecond)
+
+	// once it finishes the node has room again.
+	running.Status.Phase = corev1.PodSucceeded
+	_, err = kubecli.CoreV1().Pods("ns").UpdateStatus(ctx, running, metav1.UpdateOptions{})
+	require.NoError(err)
+	require.Eventually(fits, 5*time.Second, 10*time.Millisecond)
+}
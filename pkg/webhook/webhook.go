@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// Path is the path the PlacementRequest validating webhook is served under.
+const Path = "/validate-placementrequests"
+
+// maxRequestSize caps the size of the admission reviews we are willing to
+// read. The API server itself caps objects at 3MB.
+const maxRequestSize = 4 << 20
+
+// Webhook validates PlacementRequests at admission time so schedulers get
+// synchronous errors instead of having their requests rejected once they
+// reach the controller. PlacementRequests are checked against the same
+// queue configuration the controller runs with, including who is allowed
+// to create placement requests for each queue.
+type Webhook struct {
+	logger     klog.Logger
+	queues     map[string]configapi.Queue
+	podlister  corev1listers.PodLister
+	coreclient corev1client.PodsGetter
+}
+
+// New returns a PlacementRequest validating webhook for the provided
+// configuration. Pods are looked up in the informer cache first, if not
+// found there we ask the API server as the cache may lag behind.
+func New(
+	logger klog.Logger,
+	cfg configapi.Configuration,
+	podlister corev1listers.PodLister,
+	coreclient corev1client.PodsGetter,
+) *Webhook {
+	queues := map[string]configapi.Queue{}
+	for _, queue := range cfg.Queues {
+		queues[queue.SchedulerName] = queue
+	}
+
+	return &Webhook{
+		logger:     logger,
+		queues:     queues,
+		podlister:  podlister,
+		coreclient: coreclient,
+	}
+}
+
+// ServeHTTP reads an AdmissionReview out of the request and answers it.
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(rw, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxRequestSize))
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("failed to read request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	var review admissionv1.AdmissionReview
+	if err := json.Unmarshal(body, &review); err != nil || review.Request == nil {
+		http.Error(rw, "request is not an admission review", http.StatusBadRequest)
+		return
+	}
+
+	response := w.Review(req.Context(), review.Request)
+	response.UID = review.Request.UID
+	out, err := json.Marshal(
+		admissionv1.AdmissionReview{TypeMeta: review.TypeMeta, Response: response},
+	)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("failed to encode response: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	if _, err := rw.Write(out); err != nil {
+		w.logger.Error(err, "failed to write admission response")
+	}
+}
+
+// Review decides if the PlacementRequest in the admission request is allowed.
+// Updates not touching the spec are always allowed, the pods may have been
+// bound and deleted since the PlacementRequest was created.
+func (w *Webhook) Review(ctx context.Context, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	pr := &v1alpha1.PlacementRequest{}
+	if err := json.Unmarshal(request.Object.Raw, pr); err != nil {
+		return denied(apierrors.NewBadRequest(fmt.Sprintf("failed to decode placement request: %v", err)))
+	}
+
+	if request.Operation == admissionv1.Update {
+		old := &v1alpha1.PlacementRequest{}
+		if err := json.Unmarshal(request.OldObject.Raw, old); err != nil {
+			return denied(apierrors.NewBadRequest(fmt.Sprintf("failed to decode old placement request: %v", err)))
+		}
+		if equality.Semantic.DeepEqual(old.Spec, pr.Spec) {
+			return &admissionv1.AdmissionResponse{Allowed: true}
+		}
+	}
+
+	prid := map[string]string{"name": pr.Name, "namespace": request.Namespace}
+	if err := w.Authorize(request.UserInfo, pr.Spec.SchedulerName); err != nil {
+		w.logger.V(3).Info("placement request forbidden", "obj", prid, "user", request.UserInfo.Username)
+		gr := v1alpha1.SchemeGroupVersion.WithResource("placementrequests").GroupResource()
+		return denied(apierrors.NewForbidden(gr, pr.Name, err))
+	}
+
+	if errs := w.Validate(ctx, request.Namespace, pr); len(errs) > 0 {
+		w.logger.V(3).Info("placement request denied", "obj", prid, "errors", errs.ToAggregate())
+		gk := v1alpha1.SchemeGroupVersion.WithKind("PlacementRequest").GroupKind()
+		return denied(apierrors.NewInvalid(gk, pr.Name, errs))
+	}
+
+	w.logger.V(5).Info("placement request allowed", "obj", prid)
+	return &admissionv1.AdmissionResponse{Allowed: true}
+}
+
+// Authorize checks the user is entitled to create placement requests for the
+// queue. Queues without access restrictions, or that don't exist, are open
+// to everyone.
+func (w *Webhook) Authorize(user authenticationv1.UserInfo, schedulerName string) error {
+	queue, ok := w.queues[schedulerName]
+	if !ok || queue.Access == nil {
+		return nil
+	}
+
+	for _, sa := range queue.Access.ServiceAccounts {
+		if user.Username == serviceaccount.MakeUsername(sa.Namespace, sa.Name) {
+			return nil
+		}
+	}
+
+	for _, group := range user.Groups {
+		if slices.Contains(queue.Access.Groups, group) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("user %q is not allowed to use scheduler %q", user.Username, schedulerName)
+}
+
+// Validate checks the PlacementRequest targets an existing queue, it is not
+// larger than the queue allows and that each one of its pods exists, with the
+// expected UID, and is listed only once. PlacementRequests may be validated
+// before they get a namespace so it is provided separately.
+func (w *Webhook) Validate(ctx context.Context, namespace string, pr *v1alpha1.PlacementRequest) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	queue, ok := w.queues[pr.Spec.SchedulerName]
+	if !ok {
+		known := make([]string, 0, len(w.queues))
+		for name := range w.queues {
+			known = append(known, name)
+		}
+		slices.Sort(known)
+		return append(allErrs, field.NotSupported(specPath.Child("schedulerName"), pr.Spec.SchedulerName, known))
+	}
+
+	// there is no point in looking up the pods of a request that is going
+	// to be rejected anyway.
+	if len(pr.Spec.Bindings) > int(queue.MaxSize) {
+		return append(allErrs, field.TooMany(specPath.Child("bindings"), len(pr.Spec.Bindings), int(queue.MaxSize)))
+	}
+
+	seen := sets.New[string]()
+	for idx, binding := range pr.Spec.Bindings {
+		path := specPath.Child("bindings").Index(idx)
+		if seen.Has(binding.PodName) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("podName"), binding.PodName))
+			continue
+		}
+		seen.Insert(binding.PodName)
+
+		pod, err := w.pod(ctx, namespace, binding.PodName)
+		if apierrors.IsNotFound(err) {
+			allErrs = append(allErrs, field.NotFound(path.Child("podName"), binding.PodName))
+			continue
+		} else if err != nil {
+			allErrs = append(allErrs, field.InternalError(path.Child("podName"), err))
+			continue
+		}
+
+		if pod.UID != binding.PodUID {
+			message := fmt.Sprintf("pod %s has UID %s", binding.PodName, pod.UID)
+			allErrs = append(allErrs, field.Invalid(path.Child("podUID"), binding.PodUID, message))
+		}
+	}
+
+	return allErrs
+}
+
+// pod returns the pod out of the informer cache or, if not found there, out
+// of the API server.
+func (w *Webhook) pod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	pod, err := w.podlister.Pods(namespace).Get(name)
+	if err == nil || !apierrors.IsNotFound(err) {
+		return pod, err
+	}
+	return w.coreclient.Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// denied returns an admission response denying the request with the status of
+// the provided error.
+func denied(err apierrors.APIStatus) *admissionv1.AdmissionResponse {
+	status := err.Status()
+	return &admissionv1.AdmissionResponse{Allowed: false, Result: &status}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2025 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+
+	configapi "kombiner/pkg/apis/config/v1alpha1"
+	"kombiner/pkg/apis/kombiner/v1alpha1"
+)
+
+// newTestWebhook returns a webhook for a single queue of up to three
+// bindings. Cached pods are in the informer cache while the others can only
+// be found through the API server.
+func newTestWebhook(t *testing.T, cached []*corev1.Pod, others ...runtime.Object) *Webhook {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, pod := range cached {
+		require.NoError(t, indexer.Add(pod))
+	}
+
+	cfg := configapi.Configuration{
+		Queues: []configapi.Queue{{SchedulerName: "scheduler", Weight: 1, MaxSize: 3}},
+	}
+	return New(klog.Background(), cfg, corev1listers.NewPodLister(indexer), kubefake.NewClientset(others...).CoreV1())
+}
+
+func testPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns", UID: types.UID("uid-" + name)},
+	}
+}
+
+func testPlacementRequest(scheduler string, pods ...string) *v1alpha1.PlacementRequest {
+	pr := &v1alpha1.PlacementRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns"},
+		Spec:       v1alpha1.PlacementRequestSpec{SchedulerName: scheduler},
+	}
+	for _, pod := range pods {
+		pr.Spec.Bindings = append(
+			pr.Spec.Bindings,
+			v1alpha1.Binding{PodName: pod, PodUID: types.UID("uid-" + pod), NodeName: "node"},
+		)
+	}
+	return pr
+}
+
+func TestValidate(t *testing.T) {
+	stale := testPod("stale")
+	stale.UID = "another"
+
+	bindingsPath := field.NewPath("spec", "bindings")
+	testCases := map[string]struct {
+		pr      *v1alpha1.PlacementRequest
+		wantErr field.ErrorList
+	}{
+		"valid": {
+			pr: testPlacementRequest("scheduler", "a", "b"),
+		},
+		"pod only known to the api server": {
+			pr: testPlacementRequest("scheduler", "uncached"),
+		},
+		"unknown queue": {
+			pr: testPlacementRequest("unknown", "a"),
+			wantErr: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "schedulerName"), "unknown", []string{"scheduler"}),
+			},
+		},
+		"too many bindings": {
+			pr: testPlacementRequest("scheduler", "a", "b", "c", "d"),
+			wantErr: field.ErrorList{
+				field.TooMany(bindingsPath, 4, 3),
+			},
+		},
+		"duplicated pod": {
+			pr: testPlacementRequest("scheduler", "a", "b", "a"),
+			wantErr: field.ErrorList{
+				field.Duplicate(bindingsPath.Index(2).Child("podName"), "a"),
+			},
+		},
+		"missing pod and wrong uid": {
+			pr: testPlacementRequest("scheduler", "missing", "stale"),
+			wantErr: field.ErrorList{
+				field.NotFound(bindingsPath.Index(0).Child("podName"), "missing"),
+				field.Invalid(bindingsPath.Index(1).Child("podUID"), types.UID("uid-stale"), ""),
+			},
+		},
+	}
+
+	webhook := newTestWebhook(t, []*corev1.Pod{testPod("a"), testPod("b"), stale}, testPod("uncached"))
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			errs := webhook.Validate(context.Background(), "ns", tc.pr)
+			require.Len(t, errs, len(tc.wantErr), "unexpected errors: %v", errs)
+			for i := range tc.wantErr {
+				require.Equal(t, tc.wantErr[i].Type, errs[i].Type)
+				require.Equal(t, tc.wantErr[i].Field, errs[i].Field)
+			}
+		})
+	}
+}
+
+// review sends the admission request to the webhook through http and returns
+// the response.
+func review(t *testing.T, webhook *Webhook, request *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	body, err := json.Marshal(
+		admissionv1.AdmissionReview{
+			TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+			Request:  request,
+		},
+	)
+	require.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	webhook.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var out admissionv1.AdmissionReview
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &out))
+	require.Equal(t, "AdmissionReview", out.Kind)
+	require.NotNil(t, out.Response)
+	require.Equal(t, request.UID, out.Response.UID)
+	return out.Response
+}
+
+func raw(t *testing.T, pr *v1alpha1.PlacementRequest) runtime.RawExtension {
+	data, err := json.Marshal(pr)
+	require.NoError(t, err)
+	return runtime.RawExtension{Raw: data}
+}
+
+func TestAuthorize(t *testing.T) {
+	webhook := newTestWebhook(t, nil)
+	webhook.queues["restricted"] = configapi.Queue{
+		SchedulerName: "restricted",
+		Access: &configapi.QueueAccess{
+			ServiceAccounts: []configapi.ServiceAccountReference{{Namespace: "kube-system", Name: "scheduler"}},
+			Groups:          []string{"schedulers"},
+		},
+	}
+
+	for name, tt := range map[string]struct {
+		scheduler string
+		user      authenticationv1.UserInfo
+		allowed   bool
+	}{
+		"unrestricted queue": {
+			scheduler: "scheduler",
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			allowed:   true,
+		},
+		"unknown queue": {
+			scheduler: "unknown",
+			user:      authenticationv1.UserInfo{Username: "alice"},
+			allowed:   true,
+		},
+		"allowed service account": {
+			scheduler: "restricted",
+			user:      authenticationv1.UserInfo{Username: "system:serviceaccount:kube-system:scheduler"},
+			allowed:   true,
+		},
+		"service account in another namespace": {
+			scheduler: "restricted",
+			user:      authenticationv1.UserInfo{Username: "system:serviceaccount:default:scheduler"},
+		},
+		"allowed group": {
+			scheduler: "restricted",
+			user:      authenticationv1.UserInfo{Username: "alice", Groups: []string{"devs", "schedulers"}},
+			allowed:   true,
+		},
+		"not allowed": {
+			scheduler: "restricted",
+			user:      authenticationv1.UserInfo{Username: "alice", Groups: []string{"devs"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := webhook.Authorize(tt.user, tt.scheduler)
+			if tt.allowed {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	require := require.New(t)
+	webhook := newTestWebhook(t, []*corev1.Pod{testPod("a")})
+
+	response := review(t, webhook, &admissionv1.AdmissionRequest{
+		UID:       "allowed",
+		Operation: admissionv1.Create,
+		Namespace: "ns",
+		Object:    raw(t, testPlacementRequest("scheduler", "a")),
+	})
+	require.True(response.Allowed)
+
+	response = review(t, webhook, &admissionv1.AdmissionRequest{
+		UID:       "denied",
+		Operation: admissionv1.Create,
+		Namespace: "ns",
+		Object:    raw(t, testPlacementRequest("scheduler", "a", "missing")),
+	})
+	require.False(response.Allowed)
+	require.Equal(metav1.StatusReasonInvalid, response.Result.Reason)
+	require.Contains(response.Result.Message, "spec.bindings[1].podName")
+
+	// the pod is gone but the spec hasn't changed, a status or metadata
+	// update must go through.
+	old := testPlacementRequest("scheduler", "missing")
+	updated := old.DeepCopy()
+	updated.Labels = map[string]string{"foo": "bar"}
+	response = review(t, webhook, &admissionv1.AdmissionRequest{
+		UID:       "update",
+		Operation: admissionv1.Update,
+		Namespace: "ns",
+		Object:    raw(t, updated),
+		OldObject: raw(t, old),
+	})
+	require.True(response.Allowed)
+
+	webhook.queues["scheduler"] = configapi.Queue{
+		SchedulerName: "scheduler",
+		MaxSize:       3,
+		Access:        &configapi.QueueAccess{Groups: []string{"schedulers"}},
+	}
+	response = review(t, webhook, &admissionv1.AdmissionRequest{
+		UID:       "forbidden",
+		Operation: admissionv1.Create,
+		Namespace: "ns",
+		UserInfo:  authenticationv1.UserInfo{Username: "alice"},
+		Object:    raw(t, testPlacementRequest("scheduler", "a")),
+	})
+	require.False(response.Allowed)
+	require.Equal(metav1.StatusReasonForbidden, response.Result.Reason)
+
+	recorder := httptest.NewRecorder()
+	webhook.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Path, bytes.NewReader([]byte("{}"))))
+	require.Equal(http.StatusBadRequest, recorder.Code)
+}